@@ -0,0 +1,43 @@
+package apperrors
+
+// Stable error codes returned to clients. Existing values must never change;
+// clients depend on them.
+const (
+	CodeInternal         = "internal_error"
+	CodeInvalidBody      = "invalid_request_body"
+	CodeValidationFailed = "validation_failed"
+	CodeInvalidID        = "invalid_id"
+	CodeRouteNotFound    = "route_not_found"
+
+	CodeTestimonialNotFound = "testimonial_not_found"
+	CodeSubmissionRejected  = "submission_rejected"
+	CodeEmptyContent        = "empty_content"
+	CodeNotApproved         = "testimonial_not_approved"
+	CodeAlreadyApproved     = "testimonial_already_approved"
+	CodeRevisionNotFound    = "revision_not_found"
+
+	CodePreconditionRequired = "precondition_required"
+	CodeVersionMismatch      = "version_mismatch"
+	CodeVersionConflict      = "version_conflict"
+
+	CodeMediaNotFound       = "media_not_found"
+	CodeFileRequired        = "file_required"
+	CodeFileTooLarge        = "file_too_large"
+	CodeUnsupportedFileType = "unsupported_file_type"
+	CodeInvalidImage        = "invalid_image"
+	CodeQueueUnavailable    = "queue_unavailable"
+
+	CodeReactionsUnavailable = "reactions_unavailable"
+
+	CodeCommentNotFound = "comment_not_found"
+	CodeInvalidReply    = "invalid_reply"
+
+	CodeEmailUnavailable    = "email_unavailable"
+	CodeInvalidImport       = "invalid_import_file"
+	CodeInvalidDownloadLink = "invalid_download_link"
+	CodeDownloadNotFound    = "download_not_found"
+
+	CodeInvalidLanguage       = "invalid_language"
+	CodeTranslationNotFound   = "translation_not_found"
+	CodeTranslationIsOriginal = "translation_is_original"
+)
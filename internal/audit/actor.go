@@ -0,0 +1,22 @@
+package audit
+
+import "context"
+
+// SystemActor is recorded for changes made without a caller, such as
+// scheduled jobs.
+const SystemActor = "system"
+
+type actorKey struct{}
+
+// WithActor returns a copy of ctx naming who is making changes.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// Actor returns the actor carried by ctx, or SystemActor.
+func Actor(ctx context.Context) string {
+	if actor, _ := ctx.Value(actorKey{}).(string); actor != "" {
+		return actor
+	}
+	return SystemActor
+}
@@ -3,98 +3,145 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+
+	"wisdomHouse-backend/internal/metrics"
 )
 
 type RedisClient struct {
     client *redis.Client
-    ctx    context.Context
 }
 
-// NewRedisClient creates a new Redis client
-func NewRedisClient(redisURL string, poolSize int) (*RedisClient, error) {
+// connectTimeout bounds the initial ping so startup fails fast when Redis is
+// unreachable.
+const connectTimeout = 5 * time.Second
+
+// NewRedisClient creates a new Redis client. A non-empty password overrides
+// any password in the URL.
+func NewRedisClient(ctx context.Context, redisURL, password string, poolSize int) (*RedisClient, error) {
     opts, err := redis.ParseURL(redisURL)
     if err != nil {
         return nil, fmt.Errorf("failed to parse redis URL: %w", err)
     }
     
+    if password != "" {
+        opts.Password = password
+    }
     opts.PoolSize = poolSize
     opts.MinIdleConns = 5
     
     client := redis.NewClient(opts)
-    ctx := context.Background()
+    client.AddHook(tracingHook{})
     
     // Test connection
-    if err := client.Ping(ctx).Err(); err != nil {
+    pingCtx, cancel := context.WithTimeout(ctx, connectTimeout)
+    defer cancel()
+    if err := client.Ping(pingCtx).Err(); err != nil {
         return nil, fmt.Errorf("failed to connect to redis: %w", err)
     }
     
     return &RedisClient{
         client: client,
-        ctx:    ctx,
     }, nil
 }
 
 // Basic operations
-func (r *RedisClient) Set(key string, value interface{}, expiration time.Duration) error {
-    return r.client.Set(r.ctx, key, value, expiration).Err()
+func (r *RedisClient) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+    return r.client.Set(ctx, key, value, expiration).Err()
 }
 
-func (r *RedisClient) Get(key string) (string, error) {
-    return r.client.Get(r.ctx, key).Result()
+func (r *RedisClient) Get(ctx context.Context, key string) (string, error) {
+    value, err := r.client.Get(ctx, key).Result()
+    switch {
+    case err == nil:
+        metrics.CacheRequests.WithLabelValues("hit").Inc()
+    case errors.Is(err, redis.Nil):
+        metrics.CacheRequests.WithLabelValues("miss").Inc()
+    default:
+        metrics.CacheRequests.WithLabelValues("error").Inc()
+    }
+    return value, err
 }
 
-func (r *RedisClient) Delete(key string) error {
-    return r.client.Del(r.ctx, key).Err()
+func (r *RedisClient) Delete(ctx context.Context, key string) error {
+    return r.client.Del(ctx, key).Err()
 }
 
-func (r *RedisClient) Exists(key string) bool {
-    return r.client.Exists(r.ctx, key).Val() > 0
+func (r *RedisClient) Exists(ctx context.Context, key string) bool {
+    return r.client.Exists(ctx, key).Val() > 0
 }
 
 // Cache specific operations
-func (r *RedisClient) SetJSON(key string, value interface{}, expiration time.Duration) error {
+func (r *RedisClient) SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
     jsonData, err := json.Marshal(value)
     if err != nil {
         return err
     }
-    return r.Set(key, jsonData, expiration)
+    return r.Set(ctx, key, jsonData, expiration)
 }
 
-func (r *RedisClient) GetJSON(key string, dest interface{}) error {
-    data, err := r.Get(key)
+func (r *RedisClient) GetJSON(ctx context.Context, key string, dest interface{}) error {
+    data, err := r.Get(ctx, key)
     if err != nil {
         return err
     }
     return json.Unmarshal([]byte(data), dest)
 }
 
+// IsMiss reports whether err from Get or GetJSON means the key is absent.
+func IsMiss(err error) bool {
+    return errors.Is(err, redis.Nil)
+}
+
+// Set operations
+
+// AddToSet adds member to the set at key, reports whether it was not already
+// there, and (re)sets the set to expire after expiration.
+func (r *RedisClient) AddToSet(ctx context.Context, key, member string, expiration time.Duration) (bool, error) {
+    pipe := r.client.TxPipeline()
+    addCmd := pipe.SAdd(ctx, key, member)
+    pipe.Expire(ctx, key, expiration)
+    
+    if _, err := pipe.Exec(ctx); err != nil {
+        return false, err
+    }
+    return addCmd.Val() == 1, nil
+}
+
+// RemoveFromSet removes member from the set at key and reports whether it
+// was there.
+func (r *RedisClient) RemoveFromSet(ctx context.Context, key, member string) (bool, error) {
+    removed, err := r.client.SRem(ctx, key, member).Result()
+    return removed == 1, err
+}
+
 // Rate limiting
-func (r *RedisClient) RateLimit(key string, limit int, window time.Duration) (bool, error) {
+func (r *RedisClient) RateLimit(ctx context.Context, key string, limit int, window time.Duration) (bool, error) {
     now := time.Now().UnixNano()
     windowMicro := window.Microseconds()
     
     pipe := r.client.Pipeline()
     
     // Remove old requests
-    pipe.ZRemRangeByScore(r.ctx, key, "0", fmt.Sprintf("%d", now-windowMicro))
+    pipe.ZRemRangeByScore(ctx, key, "0", fmt.Sprintf("%d", now-windowMicro))
     
     // Count current requests
-    countCmd := pipe.ZCard(r.ctx, key)
+    countCmd := pipe.ZCard(ctx, key)
     
     // Add current request
-    pipe.ZAdd(r.ctx, key, &redis.Z{
+    pipe.ZAdd(ctx, key, &redis.Z{
         Score:  float64(now),
         Member: now,
     })
     
     // Set expiry
-    pipe.Expire(r.ctx, key, window)
+    pipe.Expire(ctx, key, window)
     
-    _, err := pipe.Exec(r.ctx)
+    _, err := pipe.Exec(ctx)
     if err != nil {
         return false, err
     }
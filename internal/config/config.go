@@ -4,101 +4,396 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
 
+// Config is the application configuration. Values are layered, each source
+// overriding the previous one: built-in defaults, an optional YAML or TOML
+// file, environment variables and finally command-line flags.
+//
+// Each field carries its file key (`config`), environment variable (`env`)
+// and, for credentials, `secret:"true"` so it is redacted from dumps and may
+// also be read from a file named by the variable with a _FILE suffix.
 type Config struct {
-	Database DatabaseConfig
-	Server   ServerConfig
-	Redis    RedisConfig
-	SMTP     SMTPConfig
-	CORS     CORSConfig
-	JWT      JWTConfig
-	App      AppConfig
+	Database  DatabaseConfig  `config:"database"`
+	Server    ServerConfig    `config:"server"`
+	Redis     RedisConfig     `config:"redis"`
+	SMTP      SMTPConfig      `config:"smtp"`
+	CORS      CORSConfig      `config:"cors"`
+	JWT       JWTConfig       `config:"jwt"`
+	App       AppConfig       `config:"app"`
+	Storage   StorageConfig   `config:"storage"`
+	Upload    UploadConfig    `config:"upload"`
+	Media     MediaConfig     `config:"media"`
+	Worker    WorkerConfig    `config:"worker"`
+	Screening ScreeningConfig `config:"screening"`
+	Metrics   MetricsConfig   `config:"metrics"`
+	Tracing   TracingConfig   `config:"tracing"`
+	Trash     TrashConfig     `config:"trash"`
+	Featured  FeaturedConfig  `config:"featured"`
+	Reactions ReactionsConfig `config:"reactions"`
+	Comments  CommentsConfig  `config:"comments"`
+	Feeds     FeedsConfig     `config:"feeds"`
+	Exports   ExportsConfig   `config:"exports"`
 }
 
 type DatabaseConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	DBName   string
-	SSLMode  string
+	Host     string `config:"host" env:"DB_HOST"`
+	Port     string `config:"port" env:"DB_PORT"`
+	User     string `config:"user" env:"DB_USER"`
+	Password string `config:"password" env:"DB_PASSWORD" secret:"true"`
+	DBName   string `config:"name" env:"DB_NAME"`
+	SSLMode  string `config:"sslmode" env:"DB_SSLMODE"`
+	// StatementTimeout bounds each query; zero leaves only the caller's
+	// context in charge.
+	StatementTimeout time.Duration `config:"statement_timeout" env:"DB_STATEMENT_TIMEOUT"`
+
+	MaxOpenConns    int           `config:"max_open_conns" env:"DB_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `config:"max_idle_conns" env:"DB_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `config:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME"`
+	ConnMaxIdleTime time.Duration `config:"conn_max_idle_time" env:"DB_CONN_MAX_IDLE_TIME"`
+	// PrepareStmt caches prepared statements per connection. Disable it
+	// behind poolers such as PgBouncer in transaction mode.
+	PrepareStmt bool `config:"prepare_stmt" env:"DB_PREPARE_STMT"`
+
+	// LogLevel is silent, error, warn or info (every statement). Empty
+	// picks info in development and warn elsewhere.
+	LogLevel           string        `config:"log_level" env:"DB_LOG_LEVEL"`
+	SlowQueryThreshold time.Duration `config:"slow_query_threshold" env:"DB_SLOW_QUERY_THRESHOLD"`
+
+	// ConnectRetries is how many times startup retries a failed connection,
+	// doubling ConnectBackoff between attempts.
+	ConnectRetries int           `config:"connect_retries" env:"DB_CONNECT_RETRIES"`
+	ConnectBackoff time.Duration `config:"connect_backoff" env:"DB_CONNECT_BACKOFF"`
+
+	// ReplicaDSNs lists read replicas used for listings and search. Replicas
+	// failing their health check are skipped until they recover.
+	ReplicaDSNs           []string      `config:"replica_dsns" env:"DB_REPLICA_DSNS" secret:"true"`
+	ReplicaHealthInterval time.Duration `config:"replica_health_interval" env:"DB_REPLICA_HEALTH_INTERVAL"`
 }
 
 type ServerConfig struct {
-	Port    string
-	GinMode string
+	Port    string `config:"port" env:"PORT"`
+	GinMode string `config:"gin_mode" env:"GIN_MODE"`
+	// TrustedProxies lists the IPs or CIDRs of reverse proxies whose
+	// X-Forwarded-For header is believed. Empty trusts none, so the client
+	// IP is the connection's remote address.
+	TrustedProxies []string `config:"trusted_proxies" env:"TRUSTED_PROXIES"`
 }
 
+// RedisConfig configures the response cache. Redis is optional: when it is
+// disabled or unreachable the API serves everything from the database.
 type RedisConfig struct {
-	URL      string
-	Password string
+	Enabled  bool   `config:"enabled" env:"REDIS_ENABLED"`
+	URL      string `config:"url" env:"REDIS_URL"`
+	Password string `config:"password" env:"REDIS_PASSWORD" secret:"true"`
+	PoolSize int    `config:"pool_size" env:"REDIS_POOL_SIZE"`
 }
 
 type SMTPConfig struct {
-	Host string
-	Port string
-	User string
-	Pass string
-	From string
+	// Enabled requires the remaining SMTP settings to be present.
+	Enabled bool   `config:"enabled" env:"EMAIL_ENABLED"`
+	Host    string `config:"host" env:"SMTP_HOST"`
+	Port    string `config:"port" env:"SMTP_PORT"`
+	User    string `config:"user" env:"SMTP_USER"`
+	Pass    string `config:"pass" env:"SMTP_PASS" secret:"true"`
+	From    string `config:"from" env:"SMTP_FROM"`
 }
 
 type CORSConfig struct {
-	AllowedOrigins []string
+	AllowedOrigins []string `config:"allowed_origins" env:"ALLOWED_ORIGINS"`
 }
 
 type JWTConfig struct {
-	Secret string
+	Secret string `config:"secret" env:"JWT_SECRET" secret:"true"`
 }
 
 type AppConfig struct {
-	Environment string
-	LogLevel    string
+	Environment string `config:"environment" env:"ENVIRONMENT"`
+	LogLevel    string `config:"log_level" env:"LOG_LEVEL"`
+	LogFormat   string `config:"log_format" env:"LOG_FORMAT"`
 }
 
-func Load() (*Config, error) {
-	// Try to load .env file
-	if err := godotenv.Load(); err != nil {
-		fmt.Println("⚠️ No .env file found, using environment variables")
-	}
+// StorageConfig selects where uploads are kept. Private files such as
+// exports are never served at PublicBaseURL: the local driver keeps them in
+// PrivateDir and hands out links to DownloadBaseURL signed with
+// SigningSecret; the s3 driver keeps them under the private/ prefix of
+// S3PrivateBucket (S3Bucket when empty) and hands out presigned URLs.
+type StorageConfig struct {
+	Driver          string `config:"driver" env:"STORAGE_DRIVER"` // "local" or "s3"
+	LocalDir        string `config:"local_dir" env:"STORAGE_LOCAL_DIR"`
+	PublicBaseURL   string `config:"public_base_url" env:"STORAGE_PUBLIC_BASE_URL"`
+	PrivateDir      string `config:"private_dir" env:"STORAGE_PRIVATE_DIR"`
+	DownloadBaseURL string `config:"download_base_url" env:"STORAGE_DOWNLOAD_BASE_URL"`
+	SigningSecret   string `config:"signing_secret" env:"STORAGE_SIGNING_SECRET" secret:"true"`
+	S3Endpoint      string `config:"s3_endpoint" env:"S3_ENDPOINT"`
+	S3Region        string `config:"s3_region" env:"S3_REGION"`
+	S3Bucket        string `config:"s3_bucket" env:"S3_BUCKET"`
+	S3PrivateBucket string `config:"s3_private_bucket" env:"S3_PRIVATE_BUCKET"`
+	S3AccessKey     string `config:"s3_access_key" env:"S3_ACCESS_KEY" secret:"true"`
+	S3SecretKey     string `config:"s3_secret_key" env:"S3_SECRET_KEY" secret:"true"`
+	S3PathStyle     bool   `config:"s3_path_style" env:"S3_PATH_STYLE"`
+}
+
+type UploadConfig struct {
+	MaxImageBytes  int64  `config:"max_image_bytes" env:"UPLOAD_MAX_IMAGE_BYTES"`
+	MaxMediaBytes  int64  `config:"max_media_bytes" env:"UPLOAD_MAX_MEDIA_BYTES"`
+	MaxImportBytes int64  `config:"max_import_bytes" env:"UPLOAD_MAX_IMPORT_BYTES"`
+	TempDir        string `config:"temp_dir" env:"UPLOAD_TEMP_DIR"`
+}
+
+type MediaConfig struct {
+	FFmpegPath  string `config:"ffmpeg_path" env:"FFMPEG_PATH"`
+	FFprobePath string `config:"ffprobe_path" env:"FFPROBE_PATH"`
+}
+
+// MetricsConfig controls the Prometheus endpoint. When Port is set the
+// endpoint is served there instead of on the public API port.
+type MetricsConfig struct {
+	Enabled bool   `config:"enabled" env:"METRICS_ENABLED"`
+	Path    string `config:"path" env:"METRICS_PATH"`
+	Port    string `config:"port" env:"METRICS_PORT"`
+	Token   string `config:"token" env:"METRICS_TOKEN" secret:"true"`
+}
+
+// TracingConfig selects the OpenTelemetry exporter: "none", "stdout" or
+// "otlp". The OTLP endpoint comes from the standard OTEL_EXPORTER_OTLP_*
+// variables.
+type TracingConfig struct {
+	Exporter    string  `config:"exporter" env:"TRACING_EXPORTER"`
+	ServiceName string  `config:"service_name" env:"OTEL_SERVICE_NAME"`
+	Environment string  `config:"environment" env:"TRACING_ENVIRONMENT"` // Defaults to App.Environment
+	SampleRatio float64 `config:"sample_ratio" env:"TRACING_SAMPLE_RATIO"`
+}
+
+// TrashConfig controls how long soft-deleted testimonials are kept before the
+// retention job purges them. A zero Retention keeps them forever.
+type TrashConfig struct {
+	Retention     time.Duration `config:"retention" env:"TRASH_RETENTION"`
+	PurgeInterval time.Duration `config:"purge_interval" env:"TRASH_PURGE_INTERVAL"`
+}
 
+// FeaturedConfig controls the home page carousel served by
+// GET /testimonials/featured. CacheTTL is an upper bound: cached entries also
+// expire when the next scheduled feature starts or ends.
+type FeaturedConfig struct {
+	Limit    int           `config:"limit" env:"FEATURED_LIMIT"`
+	CacheTTL time.Duration `config:"cache_ttl" env:"FEATURED_CACHE_TTL"`
+}
+
+// ReactionsConfig configures visitor reactions. Visitors are identified by an
+// HMAC of their IP address and user agent keyed with FingerprintSecret, so raw
+// addresses are never stored. DedupTTL is how long a testimonial's record of
+// who reacted is kept after its last reaction; once it lapses, a visitor may
+// react again.
+type ReactionsConfig struct {
+	FingerprintSecret string        `config:"fingerprint_secret" env:"REACTIONS_FINGERPRINT_SECRET" secret:"true"`
+	DedupTTL          time.Duration `config:"dedup_ttl" env:"REACTIONS_DEDUP_TTL"`
+}
+
+// CommentsConfig bounds the length of comments on testimonials. Comments
+// pass through the same blocklist, link and CAPTCHA screening as
+// testimonials, configured under screening.
+type CommentsConfig struct {
+	MinLength int `config:"min_length" env:"COMMENTS_MIN_LENGTH"`
+	MaxLength int `config:"max_length" env:"COMMENTS_MAX_LENGTH"`
+}
+
+// FeedsConfig describes the public RSS, Atom and JSON feeds. Items link to
+// {SiteURL}/testimonials/{id} on the public website; BaseURL is where this
+// API is reachable, for the feeds' links to themselves. MaxAge is how long
+// readers and proxies may cache a feed before revalidating it.
+type FeedsConfig struct {
+	Title       string        `config:"title" env:"FEEDS_TITLE"`
+	Description string        `config:"description" env:"FEEDS_DESCRIPTION"`
+	SiteURL     string        `config:"site_url" env:"FEEDS_SITE_URL"`
+	BaseURL     string        `config:"base_url" env:"FEEDS_BASE_URL"`
+	Limit       int           `config:"limit" env:"FEEDS_LIMIT"`
+	MaxAge      time.Duration `config:"max_age" env:"FEEDS_MAX_AGE"`
+}
+
+// ExportsConfig controls exports built in the background. Their download
+// links stop working after LinkTTL, and every CleanupInterval the files whose
+// links have expired are deleted.
+type ExportsConfig struct {
+	LinkTTL         time.Duration `config:"link_ttl" env:"EXPORTS_LINK_TTL"`
+	CleanupInterval time.Duration `config:"cleanup_interval" env:"EXPORTS_CLEANUP_INTERVAL"`
+}
+
+type WorkerConfig struct {
+	PoolSize int `config:"pool_size" env:"WORKER_POOL_SIZE"`
+}
+
+type ScreeningConfig struct {
+	MinLength        int      `config:"min_length" env:"SCREENING_MIN_LENGTH"`
+	MaxLength        int      `config:"max_length" env:"SCREENING_MAX_LENGTH"`
+	MaxLinks         int      `config:"max_links" env:"SCREENING_MAX_LINKS"`
+	Blocklist        []string `config:"blocklist" env:"SCREENING_BLOCKLIST"`
+	CaptchaVerifyURL string   `config:"captcha_verify_url" env:"CAPTCHA_VERIFY_URL"` // Empty disables CAPTCHA verification
+	CaptchaSecret    string   `config:"captcha_secret" env:"CAPTCHA_SECRET" secret:"true"`
+}
+
+// Defaults returns the built-in configuration, suitable for local development.
+func Defaults() *Config {
 	return &Config{
 		Database: DatabaseConfig{
-			Host:     getEnv("DB_HOST", "postgres"),
-			Port:     getEnv("DB_PORT", "5432"),
-			User:     getEnv("DB_USER", "postgres"),
-			Password: getEnv("DB_PASSWORD", ""),
-			DBName:   getEnv("DB_NAME", "wisdom_church_db"),
-			SSLMode:  getEnv("DB_SSLMODE", "disable"),
+			Host:                  "postgres",
+			Port:                  "5432",
+			User:                  "postgres",
+			DBName:                "wisdom_church_db",
+			SSLMode:               "disable",
+			StatementTimeout:      5 * time.Second,
+			MaxOpenConns:          100,
+			MaxIdleConns:          10,
+			ConnMaxLifetime:       time.Hour,
+			ConnMaxIdleTime:       10 * time.Minute,
+			PrepareStmt:           true,
+			SlowQueryThreshold:    200 * time.Millisecond,
+			ConnectRetries:        5,
+			ConnectBackoff:        time.Second,
+			ReplicaHealthInterval: 10 * time.Second,
 		},
 		Server: ServerConfig{
-			Port:    getEnv("PORT", "8080"),
-			GinMode: getEnv("GIN_MODE", "debug"),
+			Port:    "8080",
+			GinMode: "debug",
 		},
 		Redis: RedisConfig{
-			URL:      getEnv("REDIS_URL", "redis://redis:6379"),
-			Password: getEnv("REDIS_PASSWORD", ""),
+			Enabled:  true,
+			URL:      "redis://redis:6379",
+			PoolSize: 10,
 		},
 		SMTP: SMTPConfig{
-			Host: getEnv("SMTP_HOST", ""),
-			Port: getEnv("SMTP_PORT", "587"),
-			User: getEnv("SMTP_USER", ""),
-			Pass: getEnv("SMTP_PASS", ""),
-			From: getEnv("SMTP_FROM", ""),
+			Port: "587",
 		},
 		CORS: CORSConfig{
-			AllowedOrigins: strings.Split(getEnv("ALLOWED_ORIGINS", "http://localhost:3000"), ","),
-		},
-		JWT: JWTConfig{
-			Secret: getEnv("JWT_SECRET", ""),
+			AllowedOrigins: []string{"http://localhost:3000"},
 		},
 		App: AppConfig{
-			Environment: getEnv("ENVIRONMENT", "development"),
-			LogLevel:    getEnv("LOG_LEVEL", "info"),
+			Environment: "development",
+			LogLevel:    "info",
+			LogFormat:   "json",
+		},
+		Storage: StorageConfig{
+			Driver:          "local",
+			LocalDir:        "./uploads",
+			PublicBaseURL:   "http://localhost:8080/uploads",
+			PrivateDir:      "./private",
+			DownloadBaseURL: "http://localhost:8080/downloads",
+			S3Endpoint:      "http://localhost:9000",
+			S3Region:        "us-east-1",
+			S3PathStyle:     true,
+		},
+		Upload: UploadConfig{
+			MaxImageBytes:  5 << 20,
+			MaxMediaBytes:  500 << 20,
+			MaxImportBytes: 20 << 20,
+			TempDir:        os.TempDir(),
+		},
+		Media: MediaConfig{
+			FFmpegPath:  "ffmpeg",
+			FFprobePath: "ffprobe",
 		},
-	}, nil
+		Worker: WorkerConfig{
+			PoolSize: 4,
+		},
+		Metrics: MetricsConfig{
+			Enabled: true,
+			Path:    "/metrics",
+		},
+		Tracing: TracingConfig{
+			Exporter:    "none",
+			ServiceName: "wisdom-house-backend",
+			SampleRatio: 1,
+		},
+		Trash: TrashConfig{
+			Retention:     30 * 24 * time.Hour,
+			PurgeInterval: 24 * time.Hour,
+		},
+		Featured: FeaturedConfig{
+			Limit:    12,
+			CacheTTL: 5 * time.Minute,
+		},
+		Reactions: ReactionsConfig{
+			DedupTTL: 90 * 24 * time.Hour,
+		},
+		Comments: CommentsConfig{
+			MinLength: 2,
+			MaxLength: 2000,
+		},
+		Feeds: FeedsConfig{
+			Title:       "Wisdom House Testimonies",
+			Description: "Testimonies of God's faithfulness shared by the Wisdom House family",
+			SiteURL:     "http://localhost:3000",
+			BaseURL:     "http://localhost:8080",
+			Limit:       50,
+			MaxAge:      15 * time.Minute,
+		},
+		Exports: ExportsConfig{
+			LinkTTL:         24 * time.Hour,
+			CleanupInterval: time.Hour,
+		},
+		Screening: ScreeningConfig{
+			MinLength: 20,
+			MaxLength: 5000,
+			MaxLinks:  1,
+		},
+	}
+}
+
+// Load builds the configuration from defaults, the file named by -config or
+// CONFIG_FILE, the environment and the flags in args, then validates it.
+func Load(args []string) (*Config, error) {
+	// Try to load .env file
+	if err := godotenv.Load(); err != nil {
+		fmt.Fprintln(os.Stderr, "⚠️ No .env file found, using environment variables")
+	}
+
+	cfg := Defaults()
+	fields := cfg.fields()
+
+	flags, configFile := newFlagSet(fields)
+	if err := flags.Parse(args); err != nil {
+		return nil, err
+	}
+
+	path := *configFile
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(path, fields); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := loadEnv(fields); err != nil {
+		return nil, err
+	}
+	if err := applyFlags(flags, fields); err != nil {
+		return nil, err
+	}
+
+	if cfg.Database.LogLevel == "" {
+		cfg.Database.LogLevel = "warn"
+		if strings.EqualFold(cfg.App.Environment, "development") {
+			cfg.Database.LogLevel = "info"
+		}
+	}
+	if cfg.Tracing.Environment == "" {
+		cfg.Tracing.Environment = cfg.App.Environment
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// IsProduction reports whether the stricter production rules apply.
+func (c *Config) IsProduction() bool {
+	return strings.EqualFold(c.App.Environment, "production")
 }
 
 func (c *DatabaseConfig) ConnectionString() string {
@@ -106,11 +401,4 @@ func (c *DatabaseConfig) ConnectionString() string {
 		"host=%s port=%s user=%s password=%s dbname=%s sslmode=%s",
 		c.Host, c.Port, c.User, c.Password, c.DBName, c.SSLMode,
 	)
-}
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
 }
\ No newline at end of file
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+const redacted = "[REDACTED]"
+
+// Dump writes the effective configuration as YAML in the same layout the
+// config file uses. Secrets are replaced with a placeholder, or left empty
+// when unset so a missing credential is still visible.
+func (c *Config) Dump(w io.Writer) error {
+	var doc yaml.MapSlice
+	sections := make(map[string]int)
+	for _, f := range c.fields() {
+		section, key, _ := strings.Cut(f.key, ".")
+		index, ok := sections[section]
+		if !ok {
+			index = len(doc)
+			sections[section] = index
+			doc = append(doc, yaml.MapItem{Key: section, Value: yaml.MapSlice{}})
+		}
+
+		value := f.value.Interface()
+		switch v := value.(type) {
+		case time.Duration:
+			value = v.String()
+		case string:
+			if f.secret && v != "" {
+				value = redacted
+			}
+		case []string:
+			if f.secret {
+				masked := make([]string, len(v))
+				for i := range v {
+					masked[i] = redacted
+				}
+				value = masked
+			}
+		}
+		doc[index].Value = append(doc[index].Value.(yaml.MapSlice), yaml.MapItem{Key: key, Value: value})
+	}
+
+	data, err := yaml.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
@@ -0,0 +1,229 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// field is one configurable leaf value, addressed by its dotted file key
+// (e.g. "database.host").
+type field struct {
+	key    string
+	env    string
+	secret bool
+	value  reflect.Value
+}
+
+// fields lists every leaf of c in declaration order.
+func (c *Config) fields() []field {
+	var fields []field
+	root := reflect.ValueOf(c).Elem()
+	for i := 0; i < root.NumField(); i++ {
+		section := root.Type().Field(i).Tag.Get("config")
+		group := root.Field(i)
+		for j := 0; j < group.NumField(); j++ {
+			tag := group.Type().Field(j).Tag
+			fields = append(fields, field{
+				key:    section + "." + tag.Get("config"),
+				env:    tag.Get("env"),
+				secret: tag.Get("secret") == "true",
+				value:  group.Field(j),
+			})
+		}
+	}
+	return fields
+}
+
+// set parses raw into the field according to its Go type.
+func (f field) set(raw string) error {
+	raw = strings.TrimSpace(raw)
+	switch f.value.Interface().(type) {
+	case string:
+		f.value.SetString(raw)
+	case []string:
+		f.value.Set(reflect.ValueOf(splitList(raw)))
+	case bool:
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a boolean", raw)
+		}
+		f.value.SetBool(parsed)
+	case time.Duration:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return fmt.Errorf("%q is not a duration (e.g. 5s, 1m30s)", raw)
+		}
+		f.value.SetInt(int64(parsed))
+	case int, int64:
+		parsed, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not an integer", raw)
+		}
+		f.value.SetInt(parsed)
+	case float64:
+		parsed, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return fmt.Errorf("%q is not a number", raw)
+		}
+		f.value.SetFloat(parsed)
+	default:
+		return fmt.Errorf("unsupported config type %s", f.value.Type())
+	}
+	return nil
+}
+
+// loadFile applies a YAML or TOML file, chosen by extension. Unknown keys are
+// rejected so a typo cannot silently fall back to a default.
+func loadFile(path string, fields []field) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var doc map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &doc)
+	case ".toml":
+		err = toml.Unmarshal(data, &doc)
+	default:
+		return fmt.Errorf("config file %s: unsupported format, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	values := make(map[string]string)
+	flatten("", doc, values)
+
+	byKey := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+
+	var errs []error
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		f, ok := byKey[key]
+		if !ok {
+			errs = append(errs, fmt.Errorf("config file %s: unknown key %q", path, key))
+			continue
+		}
+		if err := f.set(values[key]); err != nil {
+			errs = append(errs, fmt.Errorf("config file %s: %s: %w", path, key, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// flatten turns nested file sections into dotted keys. Lists become
+// comma-separated so they parse the same way as environment variables.
+func flatten(prefix string, node map[string]any, out map[string]string) {
+	for key, value := range node {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		switch v := value.(type) {
+		case map[string]any:
+			flatten(key, v, out)
+		case []any:
+			items := make([]string, len(v))
+			for i, item := range v {
+				items[i] = fmt.Sprint(item)
+			}
+			out[key] = strings.Join(items, ",")
+		case nil:
+			out[key] = ""
+		default:
+			out[key] = fmt.Sprint(v)
+		}
+	}
+}
+
+// loadEnv applies environment variables. Secrets may instead name a file
+// holding the value via VAR_FILE, as Docker and Kubernetes secrets are mounted.
+func loadEnv(fields []field) error {
+	var errs []error
+	for _, f := range fields {
+		if f.env == "" {
+			continue
+		}
+
+		raw, ok := os.LookupEnv(f.env)
+		if path := os.Getenv(f.env + "_FILE"); f.secret && path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s_FILE: %w", f.env, err))
+				continue
+			}
+			raw, ok = strings.TrimRight(string(data), "\r\n"), true
+		}
+		if !ok || raw == "" {
+			continue
+		}
+
+		if err := f.set(raw); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", f.env, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newFlagSet registers a flag per config key, e.g. -server.port=9090, plus
+// -config for the file path.
+func newFlagSet(fields []field) (*flag.FlagSet, *string) {
+	flags := flag.NewFlagSet("wisdom-house", flag.ContinueOnError)
+	configFile := flags.String("config", "", "path to a YAML or TOML config file")
+	for _, f := range fields {
+		usage := "overrides " + f.key
+		if f.env != "" {
+			usage += " (env " + f.env + ")"
+		}
+		flags.String(f.key, "", usage)
+	}
+	return flags, configFile
+}
+
+// applyFlags applies only the flags that were given explicitly.
+func applyFlags(flags *flag.FlagSet, fields []field) error {
+	byKey := make(map[string]field, len(fields))
+	for _, f := range fields {
+		byKey[f.key] = f
+	}
+
+	var errs []error
+	flags.Visit(func(fl *flag.Flag) {
+		if f, ok := byKey[fl.Name]; ok {
+			if err := f.set(fl.Value.String()); err != nil {
+				errs = append(errs, fmt.Errorf("-%s: %w", fl.Name, err))
+			}
+		}
+	})
+	return errors.Join(errs...)
+}
+
+// splitList parses a comma-separated value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,162 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// minJWTSecretLength is the shortest JWT secret accepted in production; 32
+// bytes matches the HS256 key size.
+const minJWTSecretLength = 32
+
+// maxExportLinkTTL is the longest an export download link may last; S3
+// presigned URLs are capped at seven days.
+const maxExportLinkTTL = 7 * 24 * time.Hour
+
+// Validate checks the configuration and reports every problem at once.
+// Malformed values are always rejected; missing credentials and unsafe
+// settings only fail in production, so local setups keep working with the
+// defaults.
+func (c *Config) Validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	check(validPort(c.Server.Port), "server.port: %q is not a valid port", c.Server.Port)
+	check(validPort(c.Database.Port), "database.port: %q is not a valid port", c.Database.Port)
+	check(oneOf(c.Server.GinMode, "debug", "release", "test"), "server.gin_mode: must be debug, release or test")
+	for _, proxy := range c.Server.TrustedProxies {
+		check(validIPOrCIDR(proxy), "server.trusted_proxies: %q is not an IP address or CIDR", proxy)
+	}
+	check(c.Database.StatementTimeout >= 0, "database.statement_timeout: must not be negative")
+	check(c.Database.MaxOpenConns > 0, "database.max_open_conns: must be positive")
+	check(c.Database.MaxIdleConns >= 0 && c.Database.MaxIdleConns <= c.Database.MaxOpenConns,
+		"database.max_idle_conns: must be between 0 and max_open_conns")
+	check(c.Database.ConnMaxLifetime >= 0, "database.conn_max_lifetime: must not be negative")
+	check(c.Database.ConnMaxIdleTime >= 0, "database.conn_max_idle_time: must not be negative")
+	check(oneOf(c.Database.LogLevel, "silent", "error", "warn", "info"), "database.log_level: must be silent, error, warn or info")
+	check(c.Database.SlowQueryThreshold >= 0, "database.slow_query_threshold: must not be negative")
+	check(c.Database.ConnectRetries >= 0, "database.connect_retries: must not be negative")
+	check(c.Database.ConnectBackoff >= 0, "database.connect_backoff: must not be negative")
+	if len(c.Database.ReplicaDSNs) > 0 {
+		check(c.Database.ReplicaHealthInterval > 0, "database.replica_health_interval: must be positive when replicas are configured")
+	}
+	check(oneOf(c.App.LogLevel, "debug", "info", "warn", "warning", "error"), "app.log_level: must be debug, info, warn or error")
+	check(oneOf(c.App.LogFormat, "json", "text"), "app.log_format: must be json or text")
+	check(c.Worker.PoolSize > 0, "worker.pool_size: must be positive")
+	check(c.Upload.MaxImageBytes > 0, "upload.max_image_bytes: must be positive")
+	check(c.Upload.MaxMediaBytes > 0, "upload.max_media_bytes: must be positive")
+	check(c.Upload.MaxImportBytes > 0, "upload.max_import_bytes: must be positive")
+	check(c.Screening.MinLength >= 0 && c.Screening.MinLength <= c.Screening.MaxLength,
+		"screening: min_length must be between 0 and max_length")
+	check(c.Comments.MinLength >= 0 && c.Comments.MinLength <= c.Comments.MaxLength,
+		"comments: min_length must be between 0 and max_length")
+	check(c.Tracing.SampleRatio >= 0 && c.Tracing.SampleRatio <= 1, "tracing.sample_ratio: must be between 0 and 1")
+	check(oneOf(c.Tracing.Exporter, "none", "stdout", "otlp"), "tracing.exporter: must be none, stdout or otlp")
+	check(strings.HasPrefix(c.Metrics.Path, "/"), "metrics.path: must start with /")
+	check(c.Trash.Retention >= 0, "trash.retention: must not be negative")
+	if c.Trash.Retention > 0 {
+		check(c.Trash.PurgeInterval > 0, "trash.purge_interval: must be positive when retention is set")
+	}
+	check(c.Featured.Limit > 0 && c.Featured.Limit <= 100, "featured.limit: must be between 1 and 100")
+	check(c.Featured.CacheTTL >= 0, "featured.cache_ttl: must not be negative")
+	check(c.Reactions.DedupTTL > 0, "reactions.dedup_ttl: must be positive")
+	check(c.Feeds.Title != "", "feeds.title: required")
+	check(validURL(c.Feeds.SiteURL), "feeds.site_url: %q is not a valid URL", c.Feeds.SiteURL)
+	check(validURL(c.Feeds.BaseURL), "feeds.base_url: %q is not a valid URL", c.Feeds.BaseURL)
+	check(c.Feeds.Limit > 0 && c.Feeds.Limit <= 500, "feeds.limit: must be between 1 and 500")
+	check(c.Feeds.MaxAge >= 0, "feeds.max_age: must not be negative")
+	check(c.Exports.LinkTTL > 0 && c.Exports.LinkTTL <= maxExportLinkTTL, "exports.link_ttl: must be positive and at most %s", maxExportLinkTTL)
+	check(c.Exports.CleanupInterval > 0, "exports.cleanup_interval: must be positive")
+	if c.Redis.Enabled {
+		check(c.Redis.URL != "", "redis.url: required when redis is enabled")
+		check(c.Redis.PoolSize > 0, "redis.pool_size: must be positive")
+	}
+	if c.Metrics.Port != "" {
+		check(validPort(c.Metrics.Port), "metrics.port: %q is not a valid port", c.Metrics.Port)
+	}
+
+	switch c.Storage.Driver {
+	case "local":
+		check(c.Storage.LocalDir != "", "storage.local_dir: required for the local driver")
+		check(c.Storage.PrivateDir != "" && !within(c.Storage.PrivateDir, c.Storage.LocalDir),
+			"storage.private_dir: required for the local driver and must be outside storage.local_dir")
+		check(validURL(c.Storage.DownloadBaseURL), "storage.download_base_url: %q is not a valid URL", c.Storage.DownloadBaseURL)
+	case "s3":
+		check(c.Storage.S3Bucket != "", "storage.s3_bucket: required for the s3 driver")
+		check(c.Storage.S3AccessKey != "" && c.Storage.S3SecretKey != "", "storage: s3_access_key and s3_secret_key are required for the s3 driver")
+		check(validURL(c.Storage.S3Endpoint), "storage.s3_endpoint: %q is not a valid URL", c.Storage.S3Endpoint)
+	default:
+		errs = append(errs, fmt.Errorf("storage.driver: must be local or s3"))
+	}
+
+	if c.SMTP.Enabled {
+		check(c.SMTP.Host != "", "smtp.host: required when email is enabled")
+		check(validPort(c.SMTP.Port), "smtp.port: %q is not a valid port", c.SMTP.Port)
+		check(c.SMTP.From != "", "smtp.from: required when email is enabled")
+	}
+
+	if c.IsProduction() {
+		check(len(c.JWT.Secret) >= minJWTSecretLength, "jwt.secret: must be at least %d characters in production", minJWTSecretLength)
+		check(c.Database.Password != "", "database.password: required in production")
+		check(c.Reactions.FingerprintSecret != "", "reactions.fingerprint_secret: required in production")
+		if c.Storage.Driver == "local" {
+			check(c.Storage.SigningSecret != "", "storage.signing_secret: required in production for the local driver")
+		}
+		check(c.Database.SSLMode != "disable", "database.sslmode: must not be disable in production")
+		check(c.Server.GinMode == "release", "server.gin_mode: must be release in production")
+		if c.Metrics.Enabled && c.Metrics.Port == "" {
+			check(c.Metrics.Token != "", "metrics.token: required in production when metrics share the API port")
+		}
+		for _, origin := range c.CORS.AllowedOrigins {
+			check(origin != "*", "cors.allowed_origins: wildcard is not allowed in production")
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+}
+
+func validPort(port string) bool {
+	n, err := strconv.Atoi(port)
+	return err == nil && n > 0 && n < 65536
+}
+
+func validIPOrCIDR(value string) bool {
+	if net.ParseIP(value) != nil {
+		return true
+	}
+	_, _, err := net.ParseCIDR(value)
+	return err == nil
+}
+
+func validURL(raw string) bool {
+	parsed, err := url.Parse(raw)
+	return err == nil && parsed.Scheme != "" && parsed.Host != ""
+}
+
+func oneOf(value string, options ...string) bool {
+	for _, option := range options {
+		if strings.EqualFold(value, option) {
+			return true
+		}
+	}
+	return false
+}
+
+// within reports whether path is dir or lies beneath it.
+func within(path, dir string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && (rel == "." || filepath.IsLocal(rel))
+}
@@ -1,57 +1,157 @@
 package database
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"time"
 
 	"wisdomHouse-backend/internal/config"
+	"wisdomHouse-backend/internal/metrics"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 )
 
+// Database is the primary connection, embedded so writes and consistent reads
+// use it directly, plus any read replicas used through Reader.
 type Database struct {
 	*gorm.DB
+	statementTimeout time.Duration
+	replicas         *replicaSet
 }
 
-func NewDatabase(cfg *config.DatabaseConfig) (*Database, error) {
-	dsn := cfg.ConnectionString()
+// maxConnectBackoff caps the delay between startup connection attempts.
+const maxConnectBackoff = 30 * time.Second
 
+func NewDatabase(cfg *config.DatabaseConfig, logger *slog.Logger) (*Database, error) {
 	log.Printf("🔌 Connecting to database at %s:%s...", cfg.Host, cfg.Port)
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
-	})
+	db, err := connect(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	if err := setup(db, cfg, cfg.DBName); err != nil {
+		closeDB(db)
+		return nil, err
+	}
+
+	log.Println("✅ Database connection established successfully")
 
+	replicas, err := newReplicaSet(cfg, logger)
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect to database: %w", err)
+		closeDB(db)
+		return nil, err
+	}
+
+	return &Database{DB: db, statementTimeout: cfg.StatementTimeout, replicas: replicas}, nil
+}
+
+// setup registers the instrumentation plugins and applies the pool settings.
+// name labels the connection pool metrics.
+func setup(db *gorm.DB, cfg *config.DatabaseConfig, name string) error {
+	if err := db.Use(metricsPlugin{}); err != nil {
+		return fmt.Errorf("failed to register database metrics: %w", err)
+	}
+	if err := db.Use(tracingPlugin{}); err != nil {
+		return fmt.Errorf("failed to register database tracing: %w", err)
 	}
 
 	sqlDB, err := db.DB()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get database instance: %w", err)
+		return fmt.Errorf("failed to get database instance: %w", err)
 	}
 
 	// Connection pool settings
-	sqlDB.SetMaxIdleConns(10)
-	sqlDB.SetMaxOpenConns(100)
-	sqlDB.SetConnMaxLifetime(time.Hour)
+	sqlDB.SetMaxIdleConns(cfg.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(cfg.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(cfg.ConnMaxIdleTime)
 
-	// Test connection
-	if err := sqlDB.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	if err := metrics.RegisterDBStats(sqlDB, name); err != nil {
+		return fmt.Errorf("failed to register database pool metrics: %w", err)
 	}
+	return nil
+}
 
-	log.Println("✅ Database connection established successfully")
+func newGormConfig(cfg *config.DatabaseConfig, logger *slog.Logger) *gorm.Config {
+	return &gorm.Config{
+		Logger:      newSlogLogger(logger, cfg.LogLevel, cfg.SlowQueryThreshold),
+		PrepareStmt: cfg.PrepareStmt,
+	}
+}
+
+// connect opens the database, retrying with exponential backoff so the API
+// survives Postgres coming up a few seconds after it.
+func connect(cfg *config.DatabaseConfig, logger *slog.Logger) (*gorm.DB, error) {
+	gormConfig := newGormConfig(cfg, logger)
+
+	backoff := cfg.ConnectBackoff
+	for attempt := 0; ; attempt++ {
+		// gorm.Open pings the server, so success means it is reachable
+		db, err := gorm.Open(postgres.Open(cfg.ConnectionString()), gormConfig)
+		if err == nil {
+			return db, nil
+		}
+		if db != nil {
+			closeDB(db)
+		}
+		if attempt >= cfg.ConnectRetries {
+			return nil, fmt.Errorf("failed to connect to database after %d attempts: %w", attempt+1, err)
+		}
 
-	return &Database{db}, nil
+		logger.Warn("database not reachable, retrying", "attempt", attempt+1, "retry_in", backoff, "error", err)
+		time.Sleep(backoff)
+		backoff = min(backoff*2, maxConnectBackoff)
+	}
+}
+
+// WithTimeout returns a session on the primary bound to ctx and capped by the
+// configured statement timeout. Callers must invoke the returned cancel func
+// once the statement has finished.
+func (d *Database) WithTimeout(ctx context.Context) (*gorm.DB, context.CancelFunc) {
+	if tx, ok := txFromContext(ctx); ok {
+		return withTimeout(tx, ctx, d.statementTimeout)
+	}
+	return withTimeout(d.DB, ctx, d.statementTimeout)
+}
+
+// Reader is WithTimeout for queries that tolerate replication lag, such as
+// listings and search. It uses a healthy replica when one is configured and
+// falls back to the primary otherwise. Inside a transaction it reads from the
+// transaction. Never use it to read back a write.
+func (d *Database) Reader(ctx context.Context) (*gorm.DB, context.CancelFunc) {
+	if _, ok := txFromContext(ctx); ok {
+		return d.WithTimeout(ctx)
+	}
+	if replica := d.replicas.pick(); replica != nil {
+		return withTimeout(replica, ctx, d.statementTimeout)
+	}
+	return d.WithTimeout(ctx)
+}
+
+func withTimeout(db *gorm.DB, ctx context.Context, timeout time.Duration) (*gorm.DB, context.CancelFunc) {
+	if timeout <= 0 {
+		return db.WithContext(ctx), func() {}
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	return db.WithContext(ctx), cancel
 }
 
 func (d *Database) Close() error {
+	d.replicas.close()
+
 	sqlDB, err := d.DB.DB()
 	if err != nil {
 		return err
 	}
 	return sqlDB.Close()
-}
\ No newline at end of file
+}
+
+// closeDB releases a connection pool that is being abandoned, such as when
+// startup fails after it was opened.
+func closeDB(db *gorm.DB) {
+	if sqlDB, err := db.DB(); err == nil {
+		sqlDB.Close()
+	}
+}
@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"wisdomHouse-backend/internal/config"
+)
+
+// replicaPingTimeout bounds each health check ping.
+const replicaPingTimeout = 2 * time.Second
+
+type replica struct {
+	name    string
+	db      *gorm.DB
+	healthy atomic.Bool
+}
+
+// replicaSet round-robins reads across replicas that passed their last health
+// check. A background loop re-checks them so a replica that goes down stops
+// receiving reads and one that recovers is used again.
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+	logger   *slog.Logger
+	stop     chan struct{}
+	done     sync.WaitGroup
+}
+
+// newReplicaSet opens the configured replicas. Unreachable replicas do not
+// fail startup; they start unhealthy and join once the checker reaches them.
+// It returns nil when no replicas are configured.
+func newReplicaSet(cfg *config.DatabaseConfig, logger *slog.Logger) (*replicaSet, error) {
+	if len(cfg.ReplicaDSNs) == 0 {
+		return nil, nil
+	}
+
+	set := &replicaSet{
+		logger: logger.With("component", "replicas"),
+		stop:   make(chan struct{}),
+	}
+
+	gormConfig := newGormConfig(cfg, logger)
+	gormConfig.DisableAutomaticPing = true
+
+	for i, dsn := range cfg.ReplicaDSNs {
+		name := fmt.Sprintf("%s-replica-%d", cfg.DBName, i+1)
+		db, err := gorm.Open(postgres.Open(dsn), gormConfig)
+		if err != nil {
+			set.close()
+			return nil, fmt.Errorf("failed to open replica %d: %w", i+1, err)
+		}
+		if err := setup(db, cfg, name); err != nil {
+			closeDB(db)
+			set.close()
+			return nil, err
+		}
+		set.replicas = append(set.replicas, &replica{name: name, db: db})
+	}
+
+	set.check()
+	set.done.Add(1)
+	go set.run(cfg.ReplicaHealthInterval)
+
+	return set, nil
+}
+
+// pick returns the next healthy replica, or nil when none is available.
+func (s *replicaSet) pick() *gorm.DB {
+	if s == nil {
+		return nil
+	}
+	n := len(s.replicas)
+	start := s.next.Add(1)
+	for i := 0; i < n; i++ {
+		r := s.replicas[(start+uint64(i))%uint64(n)]
+		if r.healthy.Load() {
+			return r.db
+		}
+	}
+	return nil
+}
+
+func (s *replicaSet) run(interval time.Duration) {
+	defer s.done.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.check()
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// check pings every replica and logs health transitions.
+func (s *replicaSet) check() {
+	for _, r := range s.replicas {
+		err := ping(r.db)
+		healthy := err == nil
+		if r.healthy.Swap(healthy) == healthy {
+			continue
+		}
+		if healthy {
+			s.logger.Info("replica is healthy, routing reads to it", "replica", r.name)
+		} else {
+			s.logger.Warn("replica is unhealthy, routing its reads elsewhere", "replica", r.name, "error", err)
+		}
+	}
+}
+
+func ping(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), replicaPingTimeout)
+	defer cancel()
+	return sqlDB.PingContext(ctx)
+}
+
+func (s *replicaSet) close() {
+	if s == nil {
+		return
+	}
+	close(s.stop)
+	s.done.Wait()
+	for _, r := range s.replicas {
+		closeDB(r.db)
+	}
+}
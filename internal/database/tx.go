@@ -0,0 +1,28 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type txKey struct{}
+
+// Transaction runs fn inside a database transaction on the primary. The ctx
+// passed to fn carries the transaction, and WithTimeout and Reader return it
+// for that ctx, so repositories called with it take part automatically. The
+// transaction commits when fn returns nil and rolls back otherwise. Nested
+// calls join the outer transaction.
+func (d *Database) Transaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := txFromContext(ctx); ok {
+		return fn(ctx)
+	}
+	return d.DB.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+func txFromContext(ctx context.Context) (*gorm.DB, bool) {
+	tx, ok := ctx.Value(txKey{}).(*gorm.DB)
+	return tx, ok
+}
@@ -5,13 +5,21 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/smtp"
-	"os"
 	"strings"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+
+	"wisdomHouse-backend/internal/config"
+	"wisdomHouse-backend/internal/tracing"
 )
 
+// sendTimeout bounds a whole SMTP exchange when the caller sets no deadline.
+const sendTimeout = 30 * time.Second
+
 type Sender struct {
     host   string
     port   string
@@ -21,7 +29,9 @@ type Sender struct {
     redis  *redis.Client // For rate limiting
 }
 
-func NewSender(redisURL string) (*Sender, error) {
+// NewSender builds a sender from the SMTP settings. A non-empty redisURL
+// enables per-recipient rate limiting.
+func NewSender(cfg *config.SMTPConfig, redisURL string) (*Sender, error) {
     // Create Redis client for rate limiting
     var redisClient *redis.Client
     if redisURL != "" {
@@ -32,27 +42,45 @@ func NewSender(redisURL string) (*Sender, error) {
     }
     
     return &Sender{
-        host:  os.Getenv("SMTP_HOST"),
-        port:  os.Getenv("SMTP_PORT"),
-        user:  os.Getenv("SMTP_USER"),
-        pass:  os.Getenv("SMTP_PASS"),
-        from:  os.Getenv("SMTP_FROM"),
+        host:  cfg.Host,
+        port:  cfg.Port,
+        user:  cfg.User,
+        pass:  cfg.Pass,
+        from:  cfg.From,
         redis: redisClient,
     }, nil
 }
 
 // SendHTML sends HTML email with rate limiting
-func (s *Sender) SendHTML(to, subject, body string) error {
+func (s *Sender) SendHTML(ctx context.Context, to, subject, body string) (err error) {
+    ctx, span := tracing.Tracer().Start(ctx, "smtp.send",
+        trace.WithSpanKind(trace.SpanKindClient),
+        trace.WithAttributes(
+            semconv.ServerAddress(s.host),
+            attribute.String("smtp.subject", subject),
+        ),
+    )
+    defer func() {
+        tracing.RecordError(span, err)
+        span.End()
+    }()
+    
+    if _, ok := ctx.Deadline(); !ok {
+        var cancel context.CancelFunc
+        ctx, cancel = context.WithTimeout(ctx, sendTimeout)
+        defer cancel()
+    }
+    
     // Rate limiting: max 10 emails per minute per recipient
     if s.redis != nil {
         key := fmt.Sprintf("email_rate:%s", to)
         limitKey := fmt.Sprintf("%s:limit", key)
         
         // Check rate limit
-        count, err := s.redis.Incr(context.Background(), limitKey).Result()
+        count, err := s.redis.Incr(ctx, limitKey).Result()
         if err == nil {
             if count == 1 {
-                s.redis.Expire(context.Background(), limitKey, time.Minute)
+                s.redis.Expire(ctx, limitKey, time.Minute)
             }
             if count > 10 {
                 return fmt.Errorf("rate limit exceeded for %s", to)
@@ -80,12 +108,21 @@ func (s *Sender) SendHTML(to, subject, body string) error {
     }
     
     // Connect with TLS
-    conn, err := tls.Dial("tcp", fmt.Sprintf("%s:%s", s.host, s.port), tlsConfig)
+    dialer := &tls.Dialer{Config: tlsConfig}
+    conn, err := dialer.DialContext(ctx, "tcp", fmt.Sprintf("%s:%s", s.host, s.port))
     if err != nil {
         return fmt.Errorf("TLS connection failed: %w", err)
     }
     defer conn.Close()
     
+    // net/smtp has no context support, so enforce the deadline on the
+    // connection and drop it if the caller gives up
+    if deadline, ok := ctx.Deadline(); ok {
+        conn.SetDeadline(deadline)
+    }
+    stop := context.AfterFunc(ctx, func() { conn.Close() })
+    defer stop()
+    
     client, err := smtp.NewClient(conn, s.host)
     if err != nil {
         return fmt.Errorf("SMTP client failed: %w", err)
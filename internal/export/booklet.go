@@ -0,0 +1,114 @@
+package export
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/go-pdf/fpdf"
+	"wisdomHouse-backend/internal/models"
+	"wisdomHouse-backend/internal/validation"
+)
+
+const (
+	bookletMargin = 20.0 // mm
+	// bookletKeepTogether is the space, in mm, a testimony heading needs
+	// below it; closer to the page foot it starts on the next page instead.
+	bookletKeepTogether = 40.0
+	// countAlias is replaced by the number of testimonies when the booklet is
+	// written, since the cover is laid out before they are counted.
+	countAlias = "{testimony_count}"
+)
+
+// bookletEncoder lays testimonies out as a printable A4 booklet: a cover
+// page, then one testimony after another under the submitter's display name,
+// so anonymous testimonies stay anonymous. The built-in fonts only cover
+// Windows-1252; other characters are replaced.
+type bookletEncoder struct {
+	w     io.Writer
+	pdf   *fpdf.Fpdf
+	tr    func(string) string
+	count int
+}
+
+func newBookletEncoder(w io.Writer, opts Options) *bookletEncoder {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(bookletMargin, bookletMargin, bookletMargin)
+	pdf.SetAutoPageBreak(true, bookletMargin)
+
+	title := opts.Title
+	if title == "" {
+		title = "Testimonies"
+	}
+	e := &bookletEncoder{w: w, pdf: pdf, tr: pdf.UnicodeTranslatorFromDescriptor("")}
+	pdf.SetTitle(title, true)
+	pdf.SetCreator("Wisdom House", true)
+	pdf.SetFooterFunc(func() {
+		if pdf.PageNo() == 1 {
+			return
+		}
+		pdf.SetY(-bookletMargin + 5)
+		pdf.SetFont("Helvetica", "", 9)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 5, strconv.Itoa(pdf.PageNo()-1), "", 0, "C", false, 0, "")
+	})
+
+	e.cover(title, opts)
+	return e
+}
+
+func (e *bookletEncoder) cover(title string, opts Options) {
+	pdf := e.pdf
+	pdf.AddPage()
+	pdf.SetY(100)
+	pdf.SetFont("Helvetica", "B", 28)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.MultiCell(0, 12, e.tr(title), "", "C", false)
+	pdf.Ln(6)
+	pdf.SetFont("Helvetica", "", 14)
+	pdf.SetTextColor(80, 80, 80)
+	pdf.CellFormat(0, 8, "Wisdom House Church", "", 1, "C", false, 0, "")
+	pdf.CellFormat(0, 8, countAlias+" testimonies", "", 1, "C", false, 0, "")
+	if !opts.GeneratedAt.IsZero() {
+		pdf.SetFont("Helvetica", "I", 11)
+		pdf.CellFormat(0, 8, "Compiled "+opts.GeneratedAt.Format("2 January 2006"), "", 1, "C", false, 0, "")
+	}
+	pdf.AddPage()
+}
+
+func (e *bookletEncoder) Encode(t *models.Testimonial) error {
+	pdf := e.pdf
+	pageWidth, pageHeight := pdf.GetPageSize()
+	if e.count > 0 {
+		if pdf.GetY() > pageHeight-bookletMargin-bookletKeepTogether {
+			pdf.AddPage()
+		} else {
+			left, _, right, _ := pdf.GetMargins()
+			pdf.Ln(4)
+			pdf.SetDrawColor(200, 200, 200)
+			pdf.Line(left, pdf.GetY(), pageWidth-right, pdf.GetY())
+			pdf.Ln(8)
+		}
+	}
+	e.count++
+
+	pdf.SetFont("Helvetica", "B", 15)
+	pdf.SetTextColor(0, 0, 0)
+	pdf.MultiCell(0, 8, e.tr(t.DisplayName()), "", "L", false)
+	pdf.SetFont("Helvetica", "I", 10)
+	pdf.SetTextColor(110, 110, 110)
+	pdf.CellFormat(0, 6, t.CreatedAt.Format("2 January 2006"), "", 1, "L", false, 0, "")
+	pdf.Ln(3)
+
+	pdf.SetFont("Times", "", 12)
+	pdf.SetTextColor(20, 20, 20)
+	for _, paragraph := range validation.PlainParagraphs(t.Testimony) {
+		pdf.MultiCell(0, 6, e.tr(paragraph), "", "J", false)
+		pdf.Ln(2)
+	}
+	return pdf.Error()
+}
+
+func (e *bookletEncoder) Close() error {
+	e.pdf.RegisterAlias(countAlias, strconv.Itoa(e.count))
+	return e.pdf.Output(e.w)
+}
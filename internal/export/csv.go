@@ -0,0 +1,70 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"wisdomHouse-backend/internal/models"
+	"wisdomHouse-backend/internal/validation"
+)
+
+var csvHeader = []string{
+	"id", "first_name", "last_name", "display_name", "is_anonymous", "testimony",
+	"language", "image_url", "is_approved", "is_flagged", "is_featured", "tags", "reactions",
+	"created_at", "updated_at",
+}
+
+// csvEncoder writes one row per testimonial with the testimony as plain
+// text, paragraphs separated by blank lines.
+type csvEncoder struct {
+	w *csv.Writer
+}
+
+func newCSVEncoder(w io.Writer) (*csvEncoder, error) {
+	enc := &csvEncoder{w: csv.NewWriter(w)}
+	if err := enc.w.Write(csvHeader); err != nil {
+		return nil, err
+	}
+	return enc, nil
+}
+
+func (e *csvEncoder) Encode(t *models.Testimonial) error {
+	imageURL := ""
+	if t.ImageURL != nil {
+		imageURL = *t.ImageURL
+	}
+	return e.w.Write([]string{
+		t.ID.String(),
+		csvCell(t.FirstName),
+		csvCell(t.LastName),
+		csvCell(t.DisplayName()),
+		strconv.FormatBool(t.IsAnonymous),
+		csvCell(strings.Join(validation.PlainParagraphs(t.Testimony), "\n\n")),
+		t.Language,
+		csvCell(imageURL),
+		strconv.FormatBool(t.IsApproved),
+		strconv.FormatBool(t.IsFlagged),
+		strconv.FormatBool(t.IsFeatured),
+		csvCell(strings.Join(t.Tags, ";")),
+		strconv.FormatInt(models.NewReactionCounts(t.ReactionCounts).Total, 10),
+		t.CreatedAt.UTC().Format(time.RFC3339),
+		t.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (e *csvEncoder) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// csvCell defuses submitted text that a spreadsheet would otherwise evaluate
+// as a formula.
+func csvCell(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
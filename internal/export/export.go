@@ -0,0 +1,70 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"wisdomHouse-backend/internal/models"
+)
+
+// batchSize is how many testimonials are loaded per query while exporting.
+const batchSize = 200
+
+// Source walks testimonials oldest first; satisfied by
+// repository.TestimonialRepository.
+type Source interface {
+	ExportBatches(ctx context.Context, filter models.TestimonialFilter, batchSize int, fn func([]models.Testimonial) error) error
+}
+
+// Options customise an export. Only the PDF booklet uses them.
+type Options struct {
+	Title       string // Cover title; defaults to "Testimonies"
+	GeneratedAt time.Time
+}
+
+// encoder writes testimonials one at a time. Close flushes anything buffered
+// and must be called once every testimonial has been encoded.
+type encoder interface {
+	Encode(t *models.Testimonial) error
+	Close() error
+}
+
+// Write encodes every testimonial matching filter to w and returns how many
+// were written. CSV and NDJSON are streamed batch by batch; the PDF booklet
+// is laid out in memory and written by the final flush.
+func Write(ctx context.Context, src Source, format models.ExportFormat, filter models.TestimonialFilter, w io.Writer, opts Options) (int, error) {
+	enc, err := newEncoder(format, w, opts)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	err = src.ExportBatches(ctx, filter, batchSize, func(batch []models.Testimonial) error {
+		for i := range batch {
+			if err := enc.Encode(&batch[i]); err != nil {
+				return err
+			}
+			count++
+		}
+		return ctx.Err()
+	})
+	if err != nil {
+		return count, err
+	}
+	return count, enc.Close()
+}
+
+func newEncoder(format models.ExportFormat, w io.Writer, opts Options) (encoder, error) {
+	switch format {
+	case models.ExportCSV:
+		return newCSVEncoder(w)
+	case models.ExportNDJSON:
+		return newNDJSONEncoder(w), nil
+	case models.ExportPDF:
+		return newBookletEncoder(w, opts), nil
+	default:
+		return nil, fmt.Errorf("unsupported export format %q", format)
+	}
+}
@@ -0,0 +1,28 @@
+package export
+
+import (
+	"encoding/json"
+	"io"
+
+	"wisdomHouse-backend/internal/models"
+)
+
+// ndjsonEncoder writes each testimonial as its moderator representation on
+// a line of its own.
+type ndjsonEncoder struct {
+	enc *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) *ndjsonEncoder {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &ndjsonEncoder{enc: enc}
+}
+
+func (e *ndjsonEncoder) Encode(t *models.Testimonial) error {
+	return e.enc.Encode(t.ToAdmin())
+}
+
+func (e *ndjsonEncoder) Close() error {
+	return nil
+}
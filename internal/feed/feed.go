@@ -0,0 +1,60 @@
+// Package feed renders syndication feeds of testimonials as RSS 2.0, Atom 1.0
+// and JSON Feed 1.1.
+package feed
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"wisdomHouse-backend/internal/models"
+)
+
+// Feed is a format-independent syndication feed.
+type Feed struct {
+	Title       string
+	Description string
+	SiteURL     string    // Public page the feed mirrors
+	FeedURL     string    // Where this feed is served; also its Atom ID
+	Updated     time.Time // Newest item change; zero for an empty feed
+	Items       []Item
+}
+
+// Item is one testimony in a feed. Author and ImageURL must already honour
+// the testimonial's anonymity.
+type Item struct {
+	ID          string // Stable, globally unique URN
+	URL         string
+	Title       string
+	Author      string
+	ContentHTML string // Sanitized testimony markup
+	ContentText string
+	ImageURL    string
+	Language    string
+	Categories  []string
+	Published   time.Time
+	Updated     time.Time
+}
+
+// Write renders f to w in the given format.
+func Write(w io.Writer, format models.FeedFormat, f *Feed) error {
+	switch format {
+	case models.FeedRSS:
+		return writeRSS(w, f)
+	case models.FeedAtom:
+		return writeAtom(w, f)
+	case models.FeedJSON:
+		return writeJSON(w, f)
+	default:
+		return fmt.Errorf("unsupported feed format %q", format)
+	}
+}
+
+// updated is the feed's last change for formats that require one; empty
+// feeds report the Unix epoch so their rendering stays stable.
+func (f *Feed) updated() time.Time {
+	if f.Updated.IsZero() {
+		return time.Unix(0, 0).UTC()
+	}
+	return f.Updated.UTC()
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/i18n"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/service"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+type CommentHandler struct {
+    service service.CommentService
+}
+
+func NewCommentHandler(service service.CommentService) *CommentHandler {
+    return &CommentHandler{service: service}
+}
+
+// CreateComment godoc
+// @Summary Comment on an approved testimonial
+// @Description Comments are screened for spam and held for moderation. Set parentId to reply to a top-level comment.
+// @Tags comments
+// @Accept json
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param comment body models.CreateCommentRequest true "Comment"
+// @Success 201 {object} utils.Response{data=models.PublicComment}
+// @Failure 404 {object} utils.Problem
+// @Failure 422 {object} utils.Problem
+// @Router /testimonials/{id}/comments [post]
+func (h *CommentHandler) CreateComment(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    var req models.CreateCommentRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(apperrors.FromBinding(err))
+        return
+    }
+    
+    req.ClientIP = c.ClientIP()
+    
+    comment, err := h.service.CreateComment(c.Request.Context(), id, &req)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusCreated, i18n.MsgCommentSubmitted, comment)
+}
+
+// GetComments godoc
+// @Summary Get a testimonial's approved comments
+// @Description Top-level comments, oldest first, each with its approved replies.
+// @Tags comments
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 404 {object} utils.Problem
+// @Router /testimonials/{id}/comments [get]
+func (h *CommentHandler) GetComments(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    page, limit := parsePagination(c)
+    
+    comments, total, err := h.service.GetComments(c.Request.Context(), id, page, limit)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.PaginatedSuccessResponse(c, http.StatusOK, comments, page, limit, total)
+}
+
+// AdminGetComments godoc
+// @Summary Get comments for moderation
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param status query string false "Moderation state" Enums(pending, flagged, approved, rejected)
+// @Success 200 {object} utils.PaginatedResponse
+// @Router /admin/comments [get]
+func (h *CommentHandler) AdminGetComments(c *gin.Context) {
+    h.adminList(c, nil)
+}
+
+// AdminGetTestimonialComments godoc
+// @Summary Get a testimonial's comments for moderation
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param status query string false "Moderation state" Enums(pending, flagged, approved, rejected)
+// @Success 200 {object} utils.PaginatedResponse
+// @Router /admin/testimonials/{id}/comments [get]
+func (h *CommentHandler) AdminGetTestimonialComments(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    h.adminList(c, &id)
+}
+
+func (h *CommentHandler) adminList(c *gin.Context, testimonialID *uuid.UUID) {
+    page, limit := parsePagination(c)
+    status, ok := parseCommentStatus(c)
+    if !ok {
+        return
+    }
+    filter := models.CommentFilter{
+        TestimonialID: testimonialID,
+        Status:        status,
+    }
+    
+    comments, total, err := h.service.AdminGetComments(c.Request.Context(), page, limit, filter)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.PaginatedSuccessResponse(c, http.StatusOK, comments, page, limit, total)
+}
+
+// ApproveComment godoc
+// @Summary Approve a comment
+// @Description Publishes the comment and emails its author, and for replies the author of the parent comment.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param commentId path string true "Comment ID"
+// @Success 200 {object} utils.Response{data=models.Comment}
+// @Failure 404 {object} utils.Problem
+// @Router /admin/testimonials/{id}/comments/{commentId}/approve [patch]
+func (h *CommentHandler) ApproveComment(c *gin.Context) {
+    testimonialID, commentID, ok := parseCommentIDs(c)
+    if !ok {
+        return
+    }
+    
+    comment, err := h.service.ApproveComment(c.Request.Context(), testimonialID, commentID)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgCommentApproved, comment)
+}
+
+// RejectComment godoc
+// @Summary Reject a comment
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param commentId path string true "Comment ID"
+// @Success 200 {object} utils.Response{data=models.Comment}
+// @Failure 404 {object} utils.Problem
+// @Router /admin/testimonials/{id}/comments/{commentId}/reject [patch]
+func (h *CommentHandler) RejectComment(c *gin.Context) {
+    testimonialID, commentID, ok := parseCommentIDs(c)
+    if !ok {
+        return
+    }
+    
+    comment, err := h.service.RejectComment(c.Request.Context(), testimonialID, commentID)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgCommentRejected, comment)
+}
+
+// DeleteComment godoc
+// @Summary Delete a comment and its replies
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param commentId path string true "Comment ID"
+// @Success 200 {object} utils.Response
+// @Failure 404 {object} utils.Problem
+// @Router /admin/testimonials/{id}/comments/{commentId} [delete]
+func (h *CommentHandler) DeleteComment(c *gin.Context) {
+    testimonialID, commentID, ok := parseCommentIDs(c)
+    if !ok {
+        return
+    }
+    
+    if err := h.service.DeleteComment(c.Request.Context(), testimonialID, commentID); err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgCommentDeleted, nil)
+}
+
+func parseCommentIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+    testimonialID, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return uuid.Nil, uuid.Nil, false
+    }
+    commentID, ok := parseUUIDParam(c, "commentId", "comment ID")
+    if !ok {
+        return uuid.Nil, uuid.Nil, false
+    }
+    return testimonialID, commentID, true
+}
+
+// parseCommentStatus reads the optional status query parameter. On failure
+// it records a validation error on the context and reports false.
+func parseCommentStatus(c *gin.Context) (models.CommentStatus, bool) {
+    status := models.CommentStatus(c.Query("status"))
+    switch status {
+    case models.CommentStatusAll, models.CommentStatusPending, models.CommentStatusFlagged,
+        models.CommentStatusApproved, models.CommentStatusRejected:
+        return status, true
+    default:
+        c.Error(apperrors.Validation(apperrors.CodeValidationFailed, "Invalid comment status", utils.FieldError{
+            Field:   "status",
+            Rule:    "oneof",
+            Message: "must be pending, flagged, approved or rejected",
+        }))
+        return "", false
+    }
+}
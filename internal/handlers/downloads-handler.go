@@ -0,0 +1,68 @@
+package handlers
+
+import (
+    "errors"
+    "io/fs"
+    "net/http"
+    "os"
+    "path"
+    "strings"
+
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/storage"
+)
+
+// SignedFiles opens private files through signed download links;
+// *storage.LocalStorage satisfies it.
+type SignedFiles interface {
+    OpenSigned(key, expires, signature string) (*os.File, error)
+}
+
+type DownloadHandler struct {
+    files SignedFiles
+}
+
+func NewDownloadHandler(files SignedFiles) *DownloadHandler {
+    return &DownloadHandler{files: files}
+}
+
+// Download godoc
+// @Summary Download a private file through a signed link
+// @Description Serves the links emailed for background exports when files are stored locally. Links expire.
+// @Tags downloads
+// @Produce octet-stream
+// @Param key path string true "File key"
+// @Param expires query int true "Expiry as a Unix timestamp"
+// @Param signature query string true "Link signature"
+// @Success 200 {file} file
+// @Failure 403 {object} utils.Problem
+// @Failure 404 {object} utils.Problem
+// @Router /downloads/{key} [get]
+func (h *DownloadHandler) Download(c *gin.Context) {
+    key := strings.TrimPrefix(c.Param("key"), "/")
+    file, err := h.files.OpenSigned(key, c.Query("expires"), c.Query("signature"))
+    switch {
+    case errors.Is(err, storage.ErrInvalidLink):
+        c.Error(apperrors.Forbidden(apperrors.CodeInvalidDownloadLink, "The download link is invalid or has expired"))
+        return
+    case errors.Is(err, fs.ErrNotExist):
+        c.Error(apperrors.NotFound(apperrors.CodeDownloadNotFound, "The file is no longer available"))
+        return
+    case err != nil:
+        c.Error(err)
+        return
+    }
+    defer file.Close()
+    
+    info, err := file.Stat()
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    name := path.Base(key)
+    c.Header("Content-Disposition", `attachment; filename="`+name+`"`)
+    c.Header("Cache-Control", "private, no-store")
+    http.ServeContent(c.Writer, c.Request, name, info.ModTime(), file)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+    "errors"
+    "fmt"
+    "mime/multipart"
+    "net/http"
+    "strconv"
+
+    "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+// parseUUIDParam reads a UUID path parameter. On failure it records a
+// validation error on the context and reports false.
+func parseUUIDParam(c *gin.Context, param, label string) (uuid.UUID, bool) {
+    id, err := uuid.Parse(c.Param(param))
+    if err != nil {
+        c.Error(apperrors.Validation(apperrors.CodeInvalidID, "Invalid "+label, utils.FieldError{
+            Field:   param,
+            Rule:    "uuid",
+            Message: "must be a valid UUID",
+        }))
+        return uuid.Nil, false
+    }
+    return id, true
+}
+
+// parseSort reads the optional sort query parameter. On failure it records a
+// validation error on the context and reports false.
+func parseSort(c *gin.Context) (models.TestimonialSort, bool) {
+    sort := models.TestimonialSort(c.DefaultQuery("sort", string(models.SortNewest)))
+    switch sort {
+    case models.SortNewest, models.SortFeatured, models.SortEncouraging:
+        return sort, true
+    default:
+        c.Error(apperrors.Validation(apperrors.CodeValidationFailed, "Invalid sort order", utils.FieldError{
+            Field:   "sort",
+            Rule:    "oneof",
+            Message: "must be newest, featured or encouraging",
+        }))
+        return "", false
+    }
+}
+
+// parsePagination reads the page and limit query parameters, falling back
+// to the first page of 10 for missing or out-of-range values so the response
+// echoes what was actually served.
+func parsePagination(c *gin.Context) (int, int) {
+    page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+    if err != nil || page < 1 {
+        page = 1
+    }
+    limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+    if err != nil || limit < 1 || limit > 100 {
+        limit = 10
+    }
+    return page, limit
+}
+
+// formFile reads a multipart file field. On failure it records a domain
+// error on the context and reports false.
+func formFile(c *gin.Context, field, label string) (*multipart.FileHeader, bool) {
+    fileHeader, err := c.FormFile(field)
+    if err != nil {
+        var maxBytesErr *http.MaxBytesError
+        if errors.As(err, &maxBytesErr) {
+            c.Error(apperrors.TooLarge(apperrors.CodeFileTooLarge, fmt.Sprintf("The %s exceeds the maximum upload size", label)))
+            return nil, false
+        }
+        c.Error(apperrors.Validation(apperrors.CodeFileRequired, fmt.Sprintf("A %s is required in the '%s' field", label, field)))
+        return nil, false
+    }
+    return fileHeader, true
+}
\ No newline at end of file
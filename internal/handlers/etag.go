@@ -0,0 +1,98 @@
+package handlers
+
+import (
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/apperrors"
+)
+
+// etag renders a testimonial version as a strong entity tag.
+func etag(version int64) string {
+    return fmt.Sprintf(`"%d"`, version)
+}
+
+func setETag(c *gin.Context, version int64) {
+    c.Header("ETag", etag(version))
+}
+
+// notModified answers a conditional GET with 304 when the client's
+// If-None-Match already names the current version.
+func notModified(c *gin.Context, version int64) bool {
+    return noneMatch(c, etag(version))
+}
+
+// representationETag tags one served representation of a testimonial: the
+// version, which is all If-Match compares, followed by a hash of the body,
+// which changes with reaction counts and language without a new version.
+func representationETag(version int64, body []byte) string {
+    sum := sha256.Sum256(body)
+    return fmt.Sprintf(`"%d-%s"`, version, hex.EncodeToString(sum[:8]))
+}
+
+// contentETag renders a strong entity tag for a generated body, for
+// responses that have no version of their own.
+func contentETag(body []byte) string {
+    sum := sha256.Sum256(body)
+    return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// noneMatch answers a conditional GET with 304 when the client's
+// If-None-Match already names current.
+func noneMatch(c *gin.Context, current string) bool {
+    for _, tag := range strings.Split(c.GetHeader("If-None-Match"), ",") {
+        tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+        if tag == current || tag == "*" {
+            c.Status(http.StatusNotModified)
+            return true
+        }
+    }
+    return false
+}
+
+// notModifiedSince answers a conditional GET with 304 when nothing changed
+// after the client's If-Modified-Since. As RFC 9110 13.1.3 requires, the
+// date is ignored when the client also sent If-None-Match.
+func notModifiedSince(c *gin.Context, lastModified time.Time) bool {
+    if c.GetHeader("If-None-Match") != "" {
+        return false
+    }
+    since, err := http.ParseTime(c.GetHeader("If-Modified-Since"))
+    if err != nil || lastModified.After(since) {
+        return false
+    }
+    c.Status(http.StatusNotModified)
+    return true
+}
+
+// ifMatchVersion reads the version a mutation is conditional on, from either
+// a version tag or a representation tag. The header is mandatory so clients
+// cannot overwrite changes they never saw; "*" opts out of the check and
+// yields 0. On failure it records an error on the context and reports false.
+func ifMatchVersion(c *gin.Context) (int64, bool) {
+    header := strings.TrimSpace(c.GetHeader("If-Match"))
+    if header == "" {
+        c.Error(apperrors.PreconditionRequired(apperrors.CodePreconditionRequired,
+            "An If-Match header with the testimonial's ETag is required"))
+        return 0, false
+    }
+    if header == "*" {
+        return 0, true
+    }
+
+    // Weak tags never match under If-Match (RFC 9110 13.1.1)
+    tag, _, _ := strings.Cut(strings.Trim(header, `"`), "-")
+    version, err := strconv.ParseInt(tag, 10, 64)
+    if err != nil || version <= 0 || !strings.HasPrefix(header, `"`) {
+        c.Error(apperrors.PreconditionFailed(apperrors.CodeVersionMismatch,
+            "If-Match does not match the testimonial's current ETag"))
+        return 0, false
+    }
+    return version, true
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+    "net/http"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/i18n"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/service"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+type ExportHandler struct {
+    service service.ExportService
+}
+
+func NewExportHandler(service service.ExportService) *ExportHandler {
+    return &ExportHandler{service: service}
+}
+
+// ExportTestimonials godoc
+// @Summary Download testimonials as CSV, NDJSON or a PDF booklet
+// @Description Streams every matching testimonial, oldest first. The booklet shows display names, so anonymous testimonies stay anonymous. Queue large exports with POST /admin/testimonials/exports instead.
+// @Tags admin
+// @Produce text/csv,application/x-ndjson,application/pdf
+// @Param format query string false "Export format" Enums(csv, ndjson, pdf) default(csv)
+// @Param approved query bool false "Only approved testimonials" default(true)
+// @Param flagged query bool false "Only testimonials flagged for review"
+// @Param tag query string false "Only testimonials carrying this tag"
+// @Param q query string false "Search testimony text and media transcripts"
+// @Param from query string false "Created on or after this date (YYYY-MM-DD or RFC 3339)"
+// @Param to query string false "Created before this date (YYYY-MM-DD or RFC 3339)"
+// @Success 200 {file} file
+// @Failure 400 {object} utils.Problem
+// @Router /admin/testimonials/export [get]
+func (h *ExportHandler) ExportTestimonials(c *gin.Context) {
+    format := models.ExportFormat(c.DefaultQuery("format", string(models.ExportCSV)))
+    if !format.Valid() {
+        c.Error(apperrors.Validation(apperrors.CodeValidationFailed, "Invalid export format", utils.FieldError{
+            Field:   "format",
+            Rule:    "oneof",
+            Message: "must be csv, ndjson or pdf",
+        }))
+        return
+    }
+    
+    from, ok := parseDateQuery(c, "from")
+    if !ok {
+        return
+    }
+    to, ok := parseDateQuery(c, "to")
+    if !ok {
+        return
+    }
+    
+    filter := models.TestimonialFilter{
+        ApprovedOnly: c.DefaultQuery("approved", "true") == "true",
+        FlaggedOnly:  c.Query("flagged") == "true",
+        Tag:          strings.ToLower(strings.TrimSpace(c.Query("tag"))),
+        Search:       c.Query("q"),
+        From:         from,
+        To:           to,
+    }
+    
+    w := &attachmentWriter{c: c, contentType: format.ContentType(), filename: format.FileName(time.Now())}
+    if err := h.service.ExportTestimonials(c.Request.Context(), format, filter, w); err != nil {
+        c.Error(err)
+        return
+    }
+    w.start() // An export with nothing in it still downloads as a file
+}
+
+// QueueExport godoc
+// @Summary Queue a testimonial export and email the download link
+// @Description Builds the export in the background, for booklets and other exports too large to download directly.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param export body models.ExportRequest true "Export settings"
+// @Success 202 {object} utils.Response{data=models.ExportJob}
+// @Failure 503 {object} utils.Problem
+// @Router /admin/testimonials/exports [post]
+func (h *ExportHandler) QueueExport(c *gin.Context) {
+    var req models.ExportRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(apperrors.FromBinding(err))
+        return
+    }
+    
+    req.Tag = strings.ToLower(strings.TrimSpace(req.Tag))
+    
+    job, err := h.service.QueueExport(c.Request.Context(), &req)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusAccepted, i18n.MsgExportQueued, job)
+}
+
+// parseDateQuery reads an optional date or timestamp query parameter. On
+// failure it records a validation error on the context and reports false.
+func parseDateQuery(c *gin.Context, param string) (*time.Time, bool) {
+    value := c.Query(param)
+    if value == "" {
+        return nil, true
+    }
+    for _, layout := range []string{time.DateOnly, time.RFC3339} {
+        if t, err := time.Parse(layout, value); err == nil {
+            return &t, true
+        }
+    }
+    c.Error(apperrors.Validation(apperrors.CodeValidationFailed, "Invalid date", utils.FieldError{
+        Field:   param,
+        Rule:    "datetime",
+        Message: "must be a date (YYYY-MM-DD) or RFC 3339 timestamp",
+    }))
+    return nil, false
+}
+
+// attachmentWriter sets the download headers on the first Write, so an export
+// that fails before producing output is reported as a problem instead of
+// being saved as a broken file.
+type attachmentWriter struct {
+    c           *gin.Context
+    contentType string
+    filename    string
+    started     bool
+}
+
+func (w *attachmentWriter) Write(p []byte) (int, error) {
+    w.start()
+    return w.c.Writer.Write(p)
+}
+
+func (w *attachmentWriter) start() {
+    if w.started {
+        return
+    }
+    w.started = true
+    w.c.Header("Content-Type", w.contentType)
+    w.c.Header("Content-Disposition", `attachment; filename="`+w.filename+`"`)
+    w.c.Writer.WriteHeaderNow()
+}
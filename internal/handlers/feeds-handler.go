@@ -0,0 +1,105 @@
+package handlers
+
+import (
+    "bytes"
+    "fmt"
+    "net/http"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/service"
+)
+
+type FeedHandler struct {
+    service service.FeedService
+    maxAge  time.Duration
+}
+
+func NewFeedHandler(service service.FeedService, maxAge time.Duration) *FeedHandler {
+    return &FeedHandler{service: service, maxAge: maxAge}
+}
+
+// RSS godoc
+// @Summary RSS 2.0 feed of approved testimonials
+// @Description Newest approved testimonials first. Anonymous testimonies are credited to "Anonymous" without a photo. Served under /feeds/tags/{tag}/ to include only one tag.
+// @Tags feeds
+// @Produce application/rss+xml
+// @Param tag path string false "Only testimonials carrying this tag"
+// @Param Accept-Language header string false "Preferred languages; testimonies are served in the best available translation"
+// @Param If-None-Match header string false "ETag from the last fetch"
+// @Param If-Modified-Since header string false "Last-Modified from the last fetch"
+// @Success 200 {file} file
+// @Success 304 "Not modified"
+// @Router /feeds/testimonials.rss [get]
+func (h *FeedHandler) RSS(c *gin.Context) {
+    h.serveFeed(c, models.FeedRSS)
+}
+
+// Atom godoc
+// @Summary Atom 1.0 feed of approved testimonials
+// @Description Same entries as the RSS feed.
+// @Tags feeds
+// @Produce application/atom+xml
+// @Param tag path string false "Only testimonials carrying this tag"
+// @Param Accept-Language header string false "Preferred languages; testimonies are served in the best available translation"
+// @Param If-None-Match header string false "ETag from the last fetch"
+// @Param If-Modified-Since header string false "Last-Modified from the last fetch"
+// @Success 200 {file} file
+// @Success 304 "Not modified"
+// @Router /feeds/testimonials.atom [get]
+func (h *FeedHandler) Atom(c *gin.Context) {
+    h.serveFeed(c, models.FeedAtom)
+}
+
+// JSON godoc
+// @Summary JSON Feed 1.1 of approved testimonials
+// @Description Same entries as the RSS feed.
+// @Tags feeds
+// @Produce application/feed+json
+// @Param tag path string false "Only testimonials carrying this tag"
+// @Param Accept-Language header string false "Preferred languages; testimonies are served in the best available translation"
+// @Param If-None-Match header string false "ETag from the last fetch"
+// @Param If-Modified-Since header string false "Last-Modified from the last fetch"
+// @Success 200 {file} file
+// @Success 304 "Not modified"
+// @Router /feeds/testimonials.json [get]
+func (h *FeedHandler) JSON(c *gin.Context) {
+    h.serveFeed(c, models.FeedJSON)
+}
+
+// serveFeed answers from the client's cache when it can. If-Modified-Since
+// is checked first since it only needs the last change time; otherwise the
+// feed is rendered and its ETag compared.
+func (h *FeedHandler) serveFeed(c *gin.Context, format models.FeedFormat) {
+    tag := strings.ToLower(strings.TrimSpace(c.Param("tag")))
+    ctx := c.Request.Context()
+    
+    lastModified, err := h.service.LastModified(ctx)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", int(h.maxAge.Seconds())))
+    if !lastModified.IsZero() {
+        c.Header("Last-Modified", lastModified.Format(http.TimeFormat))
+        if notModifiedSince(c, lastModified) {
+            return
+        }
+    }
+    
+    var body bytes.Buffer
+    if err := h.service.WriteFeed(ctx, format, tag, &body); err != nil {
+        c.Error(err)
+        return
+    }
+    
+    current := contentETag(body.Bytes())
+    c.Header("ETag", current)
+    if noneMatch(c, current) {
+        return
+    }
+    c.Data(http.StatusOK, format.ContentType(), body.Bytes())
+}
@@ -0,0 +1,98 @@
+package handlers
+
+import (
+    "fmt"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/i18n"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/service"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+type ImportHandler struct {
+    service        service.ImportService
+    maxImportBytes int64
+}
+
+func NewImportHandler(service service.ImportService, maxImportBytes int64) *ImportHandler {
+    return &ImportHandler{
+        service:        service,
+        maxImportBytes: maxImportBytes,
+    }
+}
+
+// ImportTestimonials godoc
+// @Summary Import testimonials from a CSV or JSON file
+// @Description Each row is validated like a public submission. Invalid rows and testimonies that already exist are skipped and reported; the rest are inserted in one transaction. With dryRun nothing is written.
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "CSV with a header row, a JSON array, or NDJSON"
+// @Param format query string false "File format; inferred from the file extension when omitted" Enums(csv, json)
+// @Param dryRun query bool false "Validate and report without importing"
+// @Success 200 {object} utils.Response{data=models.ImportResult}
+// @Failure 400 {object} utils.Problem
+// @Failure 413 {object} utils.Problem
+// @Router /admin/testimonials/import [post]
+func (h *ImportHandler) ImportTestimonials(c *gin.Context) {
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxImportBytes+multipartOverhead)
+    
+    fileHeader, ok := formFile(c, "file", "file")
+    if !ok {
+        return
+    }
+    if fileHeader.Size > h.maxImportBytes {
+        c.Error(apperrors.TooLarge(apperrors.CodeFileTooLarge, "The file exceeds the maximum import size"))
+        return
+    }
+    
+    format, ok := importFormat(c, fileHeader.Filename)
+    if !ok {
+        return
+    }
+    
+    file, err := fileHeader.Open()
+    if err != nil {
+        c.Error(fmt.Errorf("failed to open import file: %w", err))
+        return
+    }
+    defer file.Close()
+    
+    dryRun := c.Query("dryRun") == "true"
+    result, err := h.service.ImportTestimonials(c.Request.Context(), format, file, dryRun)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    message := i18n.MsgImportCompleted
+    if dryRun {
+        message = i18n.MsgImportDryRun
+    }
+    utils.SuccessResponse(c, http.StatusOK, message, result)
+}
+
+// importFormat reads the format query parameter, falling back to the file
+// extension. On failure it records a validation error on the context and
+// reports false.
+func importFormat(c *gin.Context, filename string) (models.ImportFormat, bool) {
+    format := models.ImportFormat(c.Query("format"))
+    if format == "" {
+        format = models.ImportFormatForFile(filename)
+    }
+    
+    switch format {
+    case models.ImportCSV, models.ImportJSON:
+        return format, true
+    default:
+        c.Error(apperrors.Validation(apperrors.CodeValidationFailed, "Unknown import format", utils.FieldError{
+            Field:   "format",
+            Rule:    "oneof",
+            Message: "must be csv or json, or inferable from a .csv, .json or .ndjson file name",
+        }))
+        return "", false
+    }
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+    "fmt"
+    "io"
+    "mime/multipart"
+    "net/http"
+    "os"
+
+    "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/i18n"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/service"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+type MediaHandler struct {
+    service       service.MediaService
+    maxMediaBytes int64
+    tempDir       string
+}
+
+func NewMediaHandler(service service.MediaService, maxMediaBytes int64, tempDir string) *MediaHandler {
+    return &MediaHandler{
+        service:       service,
+        maxMediaBytes: maxMediaBytes,
+        tempDir:       tempDir,
+    }
+}
+
+// UploadTestimonialMedia godoc
+// @Summary Attach a video or audio recording to a testimonial
+// @Description The recording and its transcript appear on the public testimonial once a moderator approves them.
+// @Tags media
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param file formData file true "Video or audio recording"
+// @Param transcript formData string false "Transcript of the recording"
+// @Success 202 {object} utils.Response
+// @Router /testimonials/{id}/media [post]
+func (h *MediaHandler) UploadTestimonialMedia(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxMediaBytes+multipartOverhead)
+    
+    fileHeader, ok := formFile(c, "file", "recording")
+    if !ok {
+        return
+    }
+    
+    sourcePath, err := h.saveTemp(fileHeader)
+    if err != nil {
+        c.Error(fmt.Errorf("failed to save uploaded recording: %w", err))
+        return
+    }
+    
+    var transcript *string
+    if value := c.PostForm("transcript"); value != "" {
+        transcript = &value
+    }
+    
+    record, err := h.service.UploadTestimonialMedia(c.Request.Context(), id, sourcePath, transcript)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusAccepted, i18n.MsgMediaUploaded, record)
+}
+
+// GetTestimonialMedia godoc
+// @Summary List media attached to a testimonial, including processing state
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Success 200 {object} utils.Response
+// @Router /admin/testimonials/{id}/media [get]
+func (h *MediaHandler) GetTestimonialMedia(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    records, err := h.service.GetTestimonialMedia(c.Request.Context(), id)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgMediaFetched, records)
+}
+
+// UpdateTranscript godoc
+// @Summary Set or clear the transcript of a recording
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param mediaId path string true "Media ID"
+// @Param transcript body models.UpdateMediaTranscriptRequest true "Transcript"
+// @Success 200 {object} utils.Response
+// @Router /admin/testimonials/{id}/media/{mediaId}/transcript [put]
+func (h *MediaHandler) UpdateTranscript(c *gin.Context) {
+    id, mediaID, ok := parseMediaIDs(c)
+    if !ok {
+        return
+    }
+    
+    var req models.UpdateMediaTranscriptRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(apperrors.FromBinding(err))
+        return
+    }
+    
+    record, err := h.service.UpdateTranscript(c.Request.Context(), id, mediaID, &req)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTranscriptUpdated, record)
+}
+
+// ApproveMedia godoc
+// @Summary Publish a recording and its transcript
+// @Description Uploaded recordings stay off the public testimonial until a moderator approves them. Approving clears any screening flags.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param mediaId path string true "Media ID"
+// @Success 200 {object} utils.Response
+// @Router /admin/testimonials/{id}/media/{mediaId}/approve [patch]
+func (h *MediaHandler) ApproveMedia(c *gin.Context) {
+    id, mediaID, ok := parseMediaIDs(c)
+    if !ok {
+        return
+    }
+    
+    record, err := h.service.ApproveMedia(c.Request.Context(), id, mediaID)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgMediaApproved, record)
+}
+
+// DeleteMedia godoc
+// @Summary Remove a recording from a testimonial
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param mediaId path string true "Media ID"
+// @Success 200 {object} utils.Response
+// @Router /admin/testimonials/{id}/media/{mediaId} [delete]
+func (h *MediaHandler) DeleteMedia(c *gin.Context) {
+    id, mediaID, ok := parseMediaIDs(c)
+    if !ok {
+        return
+    }
+    
+    if err := h.service.DeleteMedia(c.Request.Context(), id, mediaID); err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgMediaDeleted, nil)
+}
+
+// saveTemp copies an uploaded file to the temp directory so it can outlive
+// the request while it waits to be transcoded.
+func (h *MediaHandler) saveTemp(fileHeader *multipart.FileHeader) (string, error) {
+    src, err := fileHeader.Open()
+    if err != nil {
+        return "", err
+    }
+    defer src.Close()
+    
+    dst, err := os.CreateTemp(h.tempDir, "upload-*")
+    if err != nil {
+        return "", err
+    }
+    
+    if _, err := io.Copy(dst, src); err != nil {
+        dst.Close()
+        os.Remove(dst.Name())
+        return "", err
+    }
+    if err := dst.Close(); err != nil {
+        os.Remove(dst.Name())
+        return "", err
+    }
+    
+    return dst.Name(), nil
+}
+
+func parseMediaIDs(c *gin.Context) (uuid.UUID, uuid.UUID, bool) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return uuid.Nil, uuid.Nil, false
+    }
+    mediaID, ok := parseUUIDParam(c, "mediaId", "media ID")
+    if !ok {
+        return uuid.Nil, uuid.Nil, false
+    }
+    return id, mediaID, true
+}
\ No newline at end of file
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/i18n"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/service"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+type ReactionHandler struct {
+    service service.ReactionService
+}
+
+func NewReactionHandler(service service.ReactionService) *ReactionHandler {
+    return &ReactionHandler{service: service}
+}
+
+// React godoc
+// @Summary React to an approved testimonial
+// @Description Each visitor counts once per reaction kind; repeating a reaction leaves the counts unchanged.
+// @Tags reactions
+// @Accept json
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param reaction body models.ReactRequest true "Reaction"
+// @Success 200 {object} utils.Response{data=models.ReactionResult}
+// @Failure 404 {object} utils.Problem
+// @Failure 503 {object} utils.Problem
+// @Router /testimonials/{id}/reactions [post]
+func (h *ReactionHandler) React(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    var req models.ReactRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(apperrors.FromBinding(err))
+        return
+    }
+    
+    result, err := h.service.React(c.Request.Context(), id, req.Kind, visitor(c))
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgReactionRecorded, result)
+}
+
+// Unreact godoc
+// @Summary Withdraw a reaction
+// @Tags reactions
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param kind path string true "Reaction kind" Enums(amen, praise, prayed)
+// @Success 200 {object} utils.Response{data=models.ReactionResult}
+// @Failure 404 {object} utils.Problem
+// @Failure 503 {object} utils.Problem
+// @Router /testimonials/{id}/reactions/{kind} [delete]
+func (h *ReactionHandler) Unreact(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    kind := models.ReactionKind(c.Param("kind"))
+    if !kind.Valid() {
+        c.Error(apperrors.Validation(apperrors.CodeValidationFailed, "Invalid reaction", utils.FieldError{
+            Field:   "kind",
+            Rule:    "oneof",
+            Message: "must be amen, praise or prayed",
+        }))
+        return
+    }
+    
+    result, err := h.service.Unreact(c.Request.Context(), id, kind, visitor(c))
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgReactionWithdrawn, result)
+}
+
+// visitor identifies the caller by their connection.
+func visitor(c *gin.Context) models.Visitor {
+    return models.Visitor{ClientIP: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+}
@@ -1,22 +1,34 @@
 package handlers
 
 import (
+    "encoding/json"
+    "fmt"
+    "io"
     "net/http"
-    "strconv"
+    "strings"
 
     "github.com/gin-gonic/gin"
-    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/i18n"
     "wisdomHouse-backend/internal/models"        
     "wisdomHouse-backend/internal/service"      
     "wisdomHouse-backend/pkg/utils"             
 )
 
+// multipartOverhead is the allowance for multipart boundaries and headers on
+// top of the file itself when capping upload request bodies.
+const multipartOverhead = 1 << 20
+
 type TestimonialHandler struct {
-    service service.TestimonialService
+    service       service.TestimonialService
+    maxImageBytes int64
 }
 
-func NewTestimonialHandler(service service.TestimonialService) *TestimonialHandler {
-    return &TestimonialHandler{service: service}
+func NewTestimonialHandler(service service.TestimonialService, maxImageBytes int64) *TestimonialHandler {
+    return &TestimonialHandler{
+        service:       service,
+        maxImageBytes: maxImageBytes,
+    }
 }
 
 // CreateTestimonial godoc
@@ -31,82 +43,134 @@ func (h *TestimonialHandler) CreateTestimonial(c *gin.Context) {
     var req models.CreateTestimonialRequest
     
     if err := c.ShouldBindJSON(&req); err != nil {
-        utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+        c.Error(apperrors.FromBinding(err))
         return
     }
     
-    testimonial, err := h.service.CreateTestimonial(&req)
+    req.ClientIP = c.ClientIP()
+    
+    testimonial, err := h.service.CreateTestimonial(c.Request.Context(), &req)
     if err != nil {
-        utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to create testimonial")
+        c.Error(err)
         return
     }
     
-    utils.SuccessResponse(c, http.StatusCreated, "Testimonial created successfully", testimonial)
+    utils.SuccessResponse(c, http.StatusCreated, i18n.MsgTestimonialCreated, testimonial)
 }
 
 // GetAllTestimonials godoc
-// @Summary Get all testimonials
+// @Summary Get all approved testimonials
 // @Tags testimonials
 // @Produce json
-// @Param approved query bool false "Filter by approved status"
+// @Param q query string false "Search testimony text and media transcripts"
+// @Param sort query string false "newest, featured to list the carousel first, or encouraging for the most reactions" Enums(newest, featured, encouraging)
+// @Param Accept-Language header string false "Preferred languages; testimonies are served in the best available translation"
 // @Success 200 {object} utils.Response
 // @Router /testimonials [get]
 func (h *TestimonialHandler) GetAllTestimonials(c *gin.Context) {
-    approved := c.DefaultQuery("approved", "true") == "true"
+    sort, ok := parseSort(c)
+    if !ok {
+        return
+    }
+    
+    filter := models.TestimonialFilter{
+        Search: c.Query("q"),
+        Sort:   sort,
+    }
     
-    testimonials, err := h.service.GetAllTestimonials(approved)
+    testimonials, err := h.service.GetAllTestimonials(c.Request.Context(), filter)
     if err != nil {
-        utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch testimonials")
+        c.Error(err)
         return
     }
     
-    utils.SuccessResponse(c, http.StatusOK, "Testimonials fetched successfully", testimonials)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialsFetched, testimonials)
 }
 
 // GetPaginatedTestimonials godoc
-// @Summary Get paginated testimonials
+// @Summary Get paginated approved testimonials
 // @Tags testimonials
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Param approved query bool false "Filter by approved status"
+// @Param q query string false "Search testimony text and media transcripts"
+// @Param sort query string false "newest, featured to list the carousel first, or encouraging for the most reactions" Enums(newest, featured, encouraging)
+// @Param Accept-Language header string false "Preferred languages; testimonies are served in the best available translation"
 // @Success 200 {object} utils.PaginatedResponse
 // @Router /testimonials/paginated [get]
 func (h *TestimonialHandler) GetPaginatedTestimonials(c *gin.Context) {
-    page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-    limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
-    approved := c.DefaultQuery("approved", "true") == "true"
+    page, limit := parsePagination(c)
+    sort, ok := parseSort(c)
+    if !ok {
+        return
+    }
+    filter := models.TestimonialFilter{
+        Search: c.Query("q"),
+        Sort:   sort,
+    }
     
-    testimonials, total, err := h.service.GetPaginatedTestimonials(page, limit, approved)
+    testimonials, total, err := h.service.GetPaginatedTestimonials(c.Request.Context(), page, limit, filter)
     if err != nil {
-        utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to fetch testimonials")
+        c.Error(err)
         return
     }
     
     utils.PaginatedSuccessResponse(c, http.StatusOK, testimonials, page, limit, total)
 }
 
+// GetFeaturedTestimonials godoc
+// @Summary Get the featured testimonials for the home page carousel
+// @Description Approved testimonials that are featured and inside their feature window, in manual order.
+// @Tags testimonials
+// @Produce json
+// @Param Accept-Language header string false "Preferred languages; testimonies are served in the best available translation"
+// @Success 200 {object} utils.Response
+// @Router /testimonials/featured [get]
+func (h *TestimonialHandler) GetFeaturedTestimonials(c *gin.Context) {
+    testimonials, err := h.service.GetFeaturedTestimonials(c.Request.Context())
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgFeaturedFetched, testimonials)
+}
+
 // GetTestimonialByID godoc
 // @Summary Get testimonial by ID
 // @Tags testimonials
 // @Produce json
 // @Param id path string true "Testimonial ID"
+// @Param Accept-Language header string false "Preferred languages; testimonies are served in the best available translation"
 // @Success 200 {object} utils.Response
+// @Header 200 {string} ETag "Version and hash of the testimonial as served; send it back in If-Match"
 // @Router /testimonials/{id} [get]
 func (h *TestimonialHandler) GetTestimonialByID(c *gin.Context) {
-    id, err := uuid.Parse(c.Param("id"))
-    if err != nil {
-        utils.ErrorResponse(c, http.StatusBadRequest, "Invalid testimonial ID")
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
         return
     }
     
-    testimonial, err := h.service.GetTestimonialByID(id)
+    ctx := c.Request.Context()
+    testimonial, err := h.service.GetTestimonialByID(ctx, id)
     if err != nil {
-        utils.ErrorResponse(c, http.StatusNotFound, "Testimonial not found")
+        c.Error(err)
         return
     }
     
-    utils.SuccessResponse(c, http.StatusOK, "Testimonial fetched successfully", testimonial)
+    // Reaction counts and the negotiated language change the response
+    // without bumping the version, so the tag covers what is actually served
+    body, err := json.Marshal(testimonial)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    current := representationETag(testimonial.Version, append(body, i18n.MessageLanguage(ctx).String()...))
+    c.Header("ETag", current)
+    if noneMatch(c, current) {
+        return
+    }
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialFetched, testimonial)
 }
 
 // UpdateTestimonial godoc
@@ -115,29 +179,37 @@ func (h *TestimonialHandler) GetTestimonialByID(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Testimonial ID"
+// @Param If-Match header string true "ETag from the last fetch, or * to skip the check"
 // @Param testimonial body models.UpdateTestimonialRequest true "Updated testimonial data"
 // @Success 200 {object} utils.Response
+// @Failure 412 {object} utils.Problem
+// @Failure 428 {object} utils.Problem
 // @Router /testimonials/{id} [put]
 func (h *TestimonialHandler) UpdateTestimonial(c *gin.Context) {
-    id, err := uuid.Parse(c.Param("id"))
-    if err != nil {
-        utils.ErrorResponse(c, http.StatusBadRequest, "Invalid testimonial ID")
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    version, ok := ifMatchVersion(c)
+    if !ok {
         return
     }
     
     var req models.UpdateTestimonialRequest
     if err := c.ShouldBindJSON(&req); err != nil {
-        utils.ErrorResponse(c, http.StatusBadRequest, err.Error())
+        c.Error(apperrors.FromBinding(err))
         return
     }
     
-    testimonial, err := h.service.UpdateTestimonial(id, &req)
+    testimonial, err := h.service.UpdateTestimonial(c.Request.Context(), id, version, &req)
     if err != nil {
-        utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to update testimonial")
+        c.Error(err)
         return
     }
     
-    utils.SuccessResponse(c, http.StatusOK, "Testimonial updated successfully", testimonial)
+    setETag(c, testimonial.Version)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialUpdated, testimonial)
 }
 
 // DeleteTestimonial godoc
@@ -145,21 +217,28 @@ func (h *TestimonialHandler) UpdateTestimonial(c *gin.Context) {
 // @Tags testimonials
 // @Produce json
 // @Param id path string true "Testimonial ID"
+// @Param If-Match header string true "ETag from the last fetch, or * to skip the check"
 // @Success 200 {object} utils.Response
+// @Failure 412 {object} utils.Problem
+// @Failure 428 {object} utils.Problem
 // @Router /testimonials/{id} [delete]
 func (h *TestimonialHandler) DeleteTestimonial(c *gin.Context) {
-    id, err := uuid.Parse(c.Param("id"))
-    if err != nil {
-        utils.ErrorResponse(c, http.StatusBadRequest, "Invalid testimonial ID")
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    version, ok := ifMatchVersion(c)
+    if !ok {
         return
     }
     
-    if err := h.service.DeleteTestimonial(id); err != nil {
-        utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to delete testimonial")
+    if err := h.service.DeleteTestimonial(c.Request.Context(), id, version); err != nil {
+        c.Error(err)
         return
     }
     
-    utils.SuccessResponse(c, http.StatusOK, "Testimonial deleted successfully", nil)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialDeleted, nil)
 }
 
 // ApproveTestimonial godoc
@@ -167,20 +246,430 @@ func (h *TestimonialHandler) DeleteTestimonial(c *gin.Context) {
 // @Tags testimonials
 // @Produce json
 // @Param id path string true "Testimonial ID"
+// @Param If-Match header string true "ETag from the last fetch, or * to skip the check"
 // @Success 200 {object} utils.Response
+// @Failure 412 {object} utils.Problem
+// @Failure 428 {object} utils.Problem
 // @Router /testimonials/{id}/approve [patch]
 func (h *TestimonialHandler) ApproveTestimonial(c *gin.Context) {
-    id, err := uuid.Parse(c.Param("id"))
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    version, ok := ifMatchVersion(c)
+    if !ok {
+        return
+    }
+    
+    testimonial, err := h.service.ApproveTestimonial(c.Request.Context(), id, version)
     if err != nil {
-        utils.ErrorResponse(c, http.StatusBadRequest, "Invalid testimonial ID")
+        c.Error(err)
         return
     }
     
-    testimonial, err := h.service.ApproveTestimonial(id)
+    setETag(c, testimonial.Version)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialApproved, testimonial)
+}
+
+// FeatureTestimonial godoc
+// @Summary Set a testimonial's carousel placement and schedule
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param If-Match header string true "ETag from the last fetch, or * to skip the check"
+// @Param feature body models.FeatureTestimonialRequest true "Feature settings"
+// @Success 200 {object} utils.Response
+// @Failure 409 {object} utils.Problem
+// @Failure 412 {object} utils.Problem
+// @Failure 428 {object} utils.Problem
+// @Router /admin/testimonials/{id}/feature [put]
+func (h *TestimonialHandler) FeatureTestimonial(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    version, ok := ifMatchVersion(c)
+    if !ok {
+        return
+    }
+    
+    var req models.FeatureTestimonialRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(apperrors.FromBinding(err))
+        return
+    }
+    
+    testimonial, err := h.service.FeatureTestimonial(c.Request.Context(), id, version, &req)
     if err != nil {
-        utils.ErrorResponse(c, http.StatusInternalServerError, "Failed to approve testimonial")
+        c.Error(err)
         return
     }
     
-    utils.SuccessResponse(c, http.StatusOK, "Testimonial approved successfully", testimonial)
-}
\ No newline at end of file
+    setETag(c, testimonial.Version)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialFeatureUpdated, testimonial)
+}
+
+// UploadTestimonialImage godoc
+// @Summary Upload a photo for a testimonial awaiting moderation
+// @Description Submitters may add or replace the photo until the testimonial is approved; after that only moderators can change it.
+// @Tags testimonials
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param If-Match header string true "ETag from the last fetch, or * to skip the check"
+// @Param image formData file true "JPEG, PNG or GIF image"
+// @Success 200 {object} utils.Response
+// @Failure 409 {object} utils.Problem
+// @Failure 412 {object} utils.Problem
+// @Failure 428 {object} utils.Problem
+// @Router /testimonials/{id}/image [post]
+func (h *TestimonialHandler) UploadTestimonialImage(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    version, ok := ifMatchVersion(c)
+    if !ok {
+        return
+    }
+    
+    data, ok := h.readImage(c)
+    if !ok {
+        return
+    }
+    
+    testimonial, err := h.service.UploadTestimonialImage(c.Request.Context(), id, version, data)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    setETag(c, testimonial.Version)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgImageUploaded, testimonial)
+}
+
+// AdminUploadTestimonialImage godoc
+// @Summary Replace the photo of any testimonial
+// @Tags admin
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param If-Match header string true "ETag from the last fetch, or * to skip the check"
+// @Param image formData file true "JPEG, PNG or GIF image"
+// @Success 200 {object} utils.Response
+// @Failure 412 {object} utils.Problem
+// @Failure 428 {object} utils.Problem
+// @Router /admin/testimonials/{id}/image [post]
+func (h *TestimonialHandler) AdminUploadTestimonialImage(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    version, ok := ifMatchVersion(c)
+    if !ok {
+        return
+    }
+    
+    data, ok := h.readImage(c)
+    if !ok {
+        return
+    }
+    
+    testimonial, err := h.service.AdminUploadTestimonialImage(c.Request.Context(), id, version, data)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    setETag(c, testimonial.Version)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgImageUploaded, testimonial)
+}
+
+// readImage reads the uploaded image field within the size limit. On failure
+// it records an error on the context and reports false.
+func (h *TestimonialHandler) readImage(c *gin.Context) ([]byte, bool) {
+    c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.maxImageBytes+multipartOverhead)
+    
+    fileHeader, ok := formFile(c, "image", "image")
+    if !ok {
+        return nil, false
+    }
+    if fileHeader.Size > h.maxImageBytes {
+        c.Error(apperrors.TooLarge(apperrors.CodeFileTooLarge, "The image exceeds the maximum upload size"))
+        return nil, false
+    }
+    
+    file, err := fileHeader.Open()
+    if err != nil {
+        c.Error(fmt.Errorf("failed to open uploaded image: %w", err))
+        return nil, false
+    }
+    defer file.Close()
+    
+    data, err := io.ReadAll(io.LimitReader(file, h.maxImageBytes+1))
+    if err != nil {
+        c.Error(fmt.Errorf("failed to read uploaded image: %w", err))
+        return nil, false
+    }
+    return data, true
+}
+
+// AdminGetAllTestimonials godoc
+// @Summary Get all testimonials including submitter identity
+// @Tags admin
+// @Produce json
+// @Param approved query bool false "Filter by approved status"
+// @Param flagged query bool false "Only testimonials flagged for review"
+// @Param tag query string false "Only testimonials carrying this tag"
+// @Param q query string false "Search testimony text and media transcripts"
+// @Param sort query string false "newest, featured to list the carousel first, or encouraging for the most reactions" Enums(newest, featured, encouraging)
+// @Success 200 {object} utils.Response
+// @Router /admin/testimonials [get]
+func (h *TestimonialHandler) AdminGetAllTestimonials(c *gin.Context) {
+    sort, ok := parseSort(c)
+    if !ok {
+        return
+    }
+    
+    filter := models.TestimonialFilter{
+        ApprovedOnly: c.DefaultQuery("approved", "false") == "true",
+        FlaggedOnly:  c.Query("flagged") == "true",
+        Tag:          strings.ToLower(strings.TrimSpace(c.Query("tag"))),
+        Search:       c.Query("q"),
+        Sort:         sort,
+    }
+    
+    testimonials, err := h.service.AdminGetAllTestimonials(c.Request.Context(), filter)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialsFetched, testimonials)
+}
+
+// AdminGetPaginatedTestimonials godoc
+// @Summary Get paginated testimonials including submitter identity
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param approved query bool false "Filter by approved status"
+// @Param flagged query bool false "Only testimonials flagged for review"
+// @Param tag query string false "Only testimonials carrying this tag"
+// @Param q query string false "Search testimony text and media transcripts"
+// @Param sort query string false "newest, featured to list the carousel first, or encouraging for the most reactions" Enums(newest, featured, encouraging)
+// @Success 200 {object} utils.PaginatedResponse
+// @Router /admin/testimonials/paginated [get]
+func (h *TestimonialHandler) AdminGetPaginatedTestimonials(c *gin.Context) {
+    page, limit := parsePagination(c)
+    sort, ok := parseSort(c)
+    if !ok {
+        return
+    }
+    filter := models.TestimonialFilter{
+        ApprovedOnly: c.DefaultQuery("approved", "false") == "true",
+        FlaggedOnly:  c.Query("flagged") == "true",
+        Tag:          strings.ToLower(strings.TrimSpace(c.Query("tag"))),
+        Search:       c.Query("q"),
+        Sort:         sort,
+    }
+    
+    testimonials, total, err := h.service.AdminGetPaginatedTestimonials(c.Request.Context(), page, limit, filter)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.PaginatedSuccessResponse(c, http.StatusOK, testimonials, page, limit, total)
+}
+
+// AdminGetTestimonialByID godoc
+// @Summary Get testimonial by ID including submitter identity
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Success 200 {object} utils.Response
+// @Header 200 {string} ETag "Current version of the testimonial"
+// @Router /admin/testimonials/{id} [get]
+func (h *TestimonialHandler) AdminGetTestimonialByID(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    testimonial, err := h.service.AdminGetTestimonialByID(c.Request.Context(), id)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    setETag(c, testimonial.Version)
+    if notModified(c, testimonial.Version) {
+        return
+    }
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialFetched, testimonial)
+}
+
+// BulkModerate godoc
+// @Summary Approve, reject, delete, tag or feature many testimonials at once
+// @Description Applies one action to every listed testimonial in a single transaction and reports the outcome per item. Items that are missing or not eligible fail individually; any other error rolls back the whole request.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param request body models.BulkModerationRequest true "Action and testimonial IDs"
+// @Success 200 {object} utils.Response{data=models.BulkModerationResult}
+// @Failure 400 {object} utils.Problem
+// @Router /admin/testimonials/bulk [post]
+func (h *TestimonialHandler) BulkModerate(c *gin.Context) {
+    var req models.BulkModerationRequest
+    
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(apperrors.FromBinding(err))
+        return
+    }
+    
+    result, err := h.service.BulkModerate(c.Request.Context(), &req)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.Translate(c.Request.Context(), i18n.MsgBulkApplied, result.Action, result.Succeeded, result.Failed), result)
+}
+
+// GetTestimonialRevisions godoc
+// @Summary List a testimonial's edit history
+// @Description Each revision records who made a change, when, the fields that changed and the resulting content. Newest first.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse
+// @Failure 404 {object} utils.Problem
+// @Router /admin/testimonials/{id}/revisions [get]
+func (h *TestimonialHandler) GetTestimonialRevisions(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    page, limit := parsePagination(c)
+    
+    revisions, total, err := h.service.GetTestimonialRevisions(c.Request.Context(), id, page, limit)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.PaginatedSuccessResponse(c, http.StatusOK, revisions, page, limit, total)
+}
+
+// RevertTestimonial godoc
+// @Summary Revert a testimonial's content to an earlier revision
+// @Description Restores the names, testimony, language, photo and anonymity recorded in the revision. Moderation state is unchanged.
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param revisionId path string true "Revision ID"
+// @Param If-Match header string true "ETag from the last fetch, or * to skip the check"
+// @Success 200 {object} utils.Response
+// @Failure 404 {object} utils.Problem
+// @Failure 412 {object} utils.Problem
+// @Failure 428 {object} utils.Problem
+// @Router /admin/testimonials/{id}/revisions/{revisionId}/revert [post]
+func (h *TestimonialHandler) RevertTestimonial(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    revisionID, ok := parseUUIDParam(c, "revisionId", "revision ID")
+    if !ok {
+        return
+    }
+    
+    version, ok := ifMatchVersion(c)
+    if !ok {
+        return
+    }
+    
+    testimonial, err := h.service.RevertTestimonial(c.Request.Context(), id, revisionID, version)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    setETag(c, testimonial.Version)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialReverted, testimonial)
+}
+
+// AdminGetDeletedTestimonials godoc
+// @Summary List testimonials in the trash
+// @Tags admin
+// @Produce json
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse
+// @Router /admin/testimonials/trash [get]
+func (h *TestimonialHandler) AdminGetDeletedTestimonials(c *gin.Context) {
+    page, limit := parsePagination(c)
+    
+    testimonials, total, err := h.service.AdminGetDeletedTestimonials(c.Request.Context(), page, limit)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.PaginatedSuccessResponse(c, http.StatusOK, testimonials, page, limit, total)
+}
+
+// RestoreTestimonial godoc
+// @Summary Restore a testimonial from the trash
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Success 200 {object} utils.Response
+// @Failure 404 {object} utils.Problem
+// @Router /admin/testimonials/{id}/restore [post]
+func (h *TestimonialHandler) RestoreTestimonial(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    testimonial, err := h.service.RestoreTestimonial(c.Request.Context(), id)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    setETag(c, testimonial.Version)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialRestored, testimonial)
+}
+
+// PurgeTestimonial godoc
+// @Summary Permanently delete a testimonial from the trash
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Success 200 {object} utils.Response
+// @Failure 404 {object} utils.Problem
+// @Router /admin/testimonials/{id}/purge [delete]
+func (h *TestimonialHandler) PurgeTestimonial(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    if err := h.service.PurgeTestimonial(c.Request.Context(), id); err != nil {
+        c.Error(err)
+        return
+    }
+    
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTestimonialPurged, nil)
+}
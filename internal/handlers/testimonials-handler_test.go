@@ -0,0 +1,81 @@
+package handlers
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "strings"
+    "testing"
+
+    "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/middleware"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/service"
+    "wisdomHouse-backend/internal/validation"
+)
+
+// versionedService serves one testimonial and applies updates only when the
+// caller names its current version.
+type versionedService struct {
+    service.TestimonialService
+    id      uuid.UUID
+    version int64
+}
+
+func (s *versionedService) GetTestimonialByID(ctx context.Context, id uuid.UUID) (*models.PublicTestimonial, error) {
+    return &models.PublicTestimonial{ID: id, Testimony: "He is faithful", Language: "en", Version: s.version}, nil
+}
+
+func (s *versionedService) UpdateTestimonial(ctx context.Context, id uuid.UUID, version int64, req *models.UpdateTestimonialRequest) (*models.AdminTestimonial, error) {
+    if version != s.version {
+        return nil, apperrors.PreconditionFailed(apperrors.CodeVersionMismatch, "stale version")
+    }
+    s.version++
+    return &models.AdminTestimonial{ID: id, Version: s.version}, nil
+}
+
+func TestGetThenUpdateWithFetchedETag(t *testing.T) {
+    gin.SetMode(gin.TestMode)
+    if err := validation.Register(); err != nil {
+        t.Fatal(err)
+    }
+
+    svc := &versionedService{id: uuid.New(), version: 3}
+    handler := NewTestimonialHandler(svc, 1<<20)
+    router := gin.New()
+    router.Use(middleware.ErrorHandler())
+    router.GET("/testimonials/:id", handler.GetTestimonialByID)
+    router.PUT("/testimonials/:id", handler.UpdateTestimonial)
+    path := "/testimonials/" + svc.id.String()
+
+    get := httptest.NewRecorder()
+    router.ServeHTTP(get, httptest.NewRequest(http.MethodGet, path, nil))
+    if get.Code != http.StatusOK {
+        t.Fatalf("GET status = %d, want 200", get.Code)
+    }
+    tag := get.Header().Get("ETag")
+    if tag == "" {
+        t.Fatal("GET returned no ETag")
+    }
+
+    put := httptest.NewRecorder()
+    req := httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{"isFlagged": false}`))
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("If-Match", tag)
+    router.ServeHTTP(put, req)
+    if put.Code != http.StatusOK {
+        t.Fatalf("PUT with If-Match %s: status = %d, want 200; body %s", tag, put.Code, put.Body)
+    }
+
+    // The tag from before the update is now stale
+    stale := httptest.NewRecorder()
+    req = httptest.NewRequest(http.MethodPut, path, strings.NewReader(`{"isFlagged": false}`))
+    req.Header.Set("Content-Type", "application/json")
+    req.Header.Set("If-Match", tag)
+    router.ServeHTTP(stale, req)
+    if stale.Code != http.StatusPreconditionFailed {
+        t.Fatalf("PUT with stale If-Match: status = %d, want 412", stale.Code)
+    }
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/i18n"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+// SaveTranslation godoc
+// @Summary Add or replace a translation of a testimony
+// @Description Public endpoints serve the translation that best matches the visitor's Accept-Language.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param lang path string true "BCP 47 language tag, e.g. fr or pt-BR"
+// @Param If-Match header string true "ETag from the last fetch, or * to skip the check"
+// @Param translation body models.TranslationRequest true "Translated testimony"
+// @Success 200 {object} utils.Response
+// @Failure 404 {object} utils.Problem
+// @Failure 409 {object} utils.Problem
+// @Failure 412 {object} utils.Problem
+// @Failure 428 {object} utils.Problem
+// @Router /admin/testimonials/{id}/translations/{lang} [put]
+func (h *TestimonialHandler) SaveTranslation(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    language, ok := parseLanguageParam(c)
+    if !ok {
+        return
+    }
+    
+    version, ok := ifMatchVersion(c)
+    if !ok {
+        return
+    }
+    
+    var req models.TranslationRequest
+    if err := c.ShouldBindJSON(&req); err != nil {
+        c.Error(apperrors.FromBinding(err))
+        return
+    }
+    
+    testimonial, err := h.service.SaveTranslation(c.Request.Context(), id, version, language, &req)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    setETag(c, testimonial.Version)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTranslationSaved, testimonial)
+}
+
+// DeleteTranslation godoc
+// @Summary Remove a translation of a testimony
+// @Tags admin
+// @Produce json
+// @Param id path string true "Testimonial ID"
+// @Param lang path string true "BCP 47 language tag"
+// @Param If-Match header string true "ETag from the last fetch, or * to skip the check"
+// @Success 200 {object} utils.Response
+// @Failure 404 {object} utils.Problem
+// @Failure 412 {object} utils.Problem
+// @Failure 428 {object} utils.Problem
+// @Router /admin/testimonials/{id}/translations/{lang} [delete]
+func (h *TestimonialHandler) DeleteTranslation(c *gin.Context) {
+    id, ok := parseUUIDParam(c, "id", "testimonial ID")
+    if !ok {
+        return
+    }
+    
+    language, ok := parseLanguageParam(c)
+    if !ok {
+        return
+    }
+    
+    version, ok := ifMatchVersion(c)
+    if !ok {
+        return
+    }
+    
+    testimonial, err := h.service.DeleteTranslation(c.Request.Context(), id, version, language)
+    if err != nil {
+        c.Error(err)
+        return
+    }
+    
+    setETag(c, testimonial.Version)
+    utils.SuccessResponse(c, http.StatusOK, i18n.MsgTranslationDeleted, testimonial)
+}
+
+// parseLanguageParam reads the lang path parameter in canonical form. On
+// failure it records a validation error on the context and reports false.
+func parseLanguageParam(c *gin.Context) (string, bool) {
+    language, err := i18n.Canonical(c.Param("lang"))
+    if err != nil || len(language) > 35 {
+        c.Error(apperrors.Validation(apperrors.CodeInvalidLanguage, "Invalid language", utils.FieldError{
+            Field:   "lang",
+            Rule:    "bcp47_language_tag",
+            Message: "must be a BCP 47 language tag such as en or pt-BR",
+        }))
+        return "", false
+    }
+    return language, true
+}
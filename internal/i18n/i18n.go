@@ -0,0 +1,82 @@
+// Package i18n carries the caller's language preferences through a request
+// and picks the best match among the languages a response is available in.
+package i18n
+
+import (
+	"context"
+
+	"golang.org/x/text/language"
+)
+
+// DefaultLanguage is the language testimonials are assumed to be written in
+// and the one messages fall back to.
+const DefaultLanguage = "en"
+
+// maxPreferences bounds how many Accept-Language entries are considered, so
+// a hostile header cannot make matching expensive.
+const maxPreferences = 10
+
+type contextKey struct{}
+
+// WithPreferences returns a copy of ctx carrying the caller's languages, most
+// preferred first.
+func WithPreferences(ctx context.Context, prefs []language.Tag) context.Context {
+	return context.WithValue(ctx, contextKey{}, prefs)
+}
+
+// Preferences returns the languages carried by ctx, or nil.
+func Preferences(ctx context.Context) []language.Tag {
+	if ctx == nil {
+		return nil
+	}
+	prefs, _ := ctx.Value(contextKey{}).([]language.Tag)
+	return prefs
+}
+
+// ParseAcceptLanguage reads an Accept-Language header, most preferred first.
+// A malformed header is treated as expressing no preference.
+func ParseAcceptLanguage(header string) []language.Tag {
+	if header == "" {
+		return nil
+	}
+	prefs, _, err := language.ParseAcceptLanguage(header)
+	if err != nil {
+		return nil
+	}
+	if len(prefs) > maxPreferences {
+		prefs = prefs[:maxPreferences]
+	}
+	return prefs
+}
+
+// Canonical validates a BCP 47 tag and returns its canonical form, so "PT-br"
+// and "pt-BR" are stored alike.
+func Canonical(tag string) (string, error) {
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return "", err
+	}
+	return parsed.String(), nil
+}
+
+// Match returns the index of the entry in available that best serves prefs.
+// It reports false when nothing matches, in which case callers keep
+// available[0]. Entries that fail to parse never match.
+func Match(prefs []language.Tag, available []string) (int, bool) {
+	if len(prefs) == 0 || len(available) == 0 {
+		return 0, false
+	}
+	tags := make([]language.Tag, len(available))
+	for i, lang := range available {
+		tag, err := language.Parse(lang)
+		if err != nil {
+			tag = language.Und
+		}
+		tags[i] = tag
+	}
+	_, index, confidence := language.NewMatcher(tags).Match(prefs...)
+	if confidence == language.No {
+		return 0, false
+	}
+	return index, true
+}
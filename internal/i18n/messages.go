@@ -0,0 +1,208 @@
+package i18n
+
+import (
+	"context"
+	"fmt"
+
+	"golang.org/x/text/language"
+)
+
+// Message keys for the success messages in API responses. Handlers pass a
+// key and the response helpers render it in the caller's language.
+const (
+	MsgDataFetched = "data.fetched"
+
+	MsgTestimonialCreated        = "testimonial.created"
+	MsgTestimonialFetched        = "testimonial.fetched"
+	MsgTestimonialsFetched       = "testimonials.fetched"
+	MsgFeaturedFetched           = "testimonials.featured_fetched"
+	MsgTestimonialUpdated        = "testimonial.updated"
+	MsgTestimonialDeleted        = "testimonial.deleted"
+	MsgTestimonialApproved       = "testimonial.approved"
+	MsgTestimonialFeatureUpdated = "testimonial.feature_updated"
+	MsgTestimonialReverted       = "testimonial.reverted"
+	MsgTestimonialRestored       = "testimonial.restored"
+	MsgTestimonialPurged         = "testimonial.purged"
+	MsgImageUploaded             = "testimonial.image_uploaded"
+	MsgBulkApplied               = "testimonials.bulk_applied" // Action, succeeded, failed
+	MsgTranslationSaved          = "translation.saved"
+	MsgTranslationDeleted        = "translation.deleted"
+
+	MsgMediaUploaded     = "media.uploaded"
+	MsgMediaFetched      = "media.fetched"
+	MsgTranscriptUpdated = "media.transcript_updated"
+	MsgMediaApproved     = "media.approved"
+	MsgMediaDeleted      = "media.deleted"
+	MsgReactionRecorded  = "reaction.recorded"
+	MsgReactionWithdrawn = "reaction.withdrawn"
+	MsgCommentSubmitted  = "comment.submitted"
+	MsgCommentApproved   = "comment.approved"
+	MsgCommentRejected   = "comment.rejected"
+	MsgCommentDeleted    = "comment.deleted"
+	MsgExportQueued      = "export.queued"
+	MsgImportCompleted   = "import.completed"
+	MsgImportDryRun      = "import.dry_run"
+)
+
+// messageLanguages lists the languages messages are translated into; the
+// first is the fallback.
+var messageLanguages = []language.Tag{language.English, language.Spanish, language.French, language.Portuguese}
+
+var messageMatcher = language.NewMatcher(messageLanguages)
+
+var catalogs = map[language.Tag]map[string]string{
+	language.English: {
+		MsgDataFetched:               "Data fetched successfully",
+		MsgTestimonialCreated:        "Testimonial created successfully",
+		MsgTestimonialFetched:        "Testimonial fetched successfully",
+		MsgTestimonialsFetched:       "Testimonials fetched successfully",
+		MsgFeaturedFetched:           "Featured testimonials fetched successfully",
+		MsgTestimonialUpdated:        "Testimonial updated successfully",
+		MsgTestimonialDeleted:        "Testimonial deleted successfully",
+		MsgTestimonialApproved:       "Testimonial approved successfully",
+		MsgTestimonialFeatureUpdated: "Testimonial feature settings updated",
+		MsgTestimonialReverted:       "Testimonial reverted successfully",
+		MsgTestimonialRestored:       "Testimonial restored successfully",
+		MsgTestimonialPurged:         "Testimonial permanently deleted",
+		MsgImageUploaded:             "Image uploaded successfully",
+		MsgBulkApplied:               "Bulk %s applied: %d succeeded, %d failed",
+		MsgTranslationSaved:          "Translation saved successfully",
+		MsgTranslationDeleted:        "Translation deleted successfully",
+		MsgMediaUploaded:             "Recording uploaded and queued for processing",
+		MsgMediaFetched:              "Media fetched successfully",
+		MsgTranscriptUpdated:         "Transcript updated successfully",
+		MsgMediaApproved:             "Media approved successfully",
+		MsgMediaDeleted:              "Media deleted successfully",
+		MsgReactionRecorded:          "Reaction recorded",
+		MsgReactionWithdrawn:         "Reaction withdrawn",
+		MsgCommentSubmitted:          "Comment submitted for review",
+		MsgCommentApproved:           "Comment approved successfully",
+		MsgCommentRejected:           "Comment rejected successfully",
+		MsgCommentDeleted:            "Comment deleted successfully",
+		MsgExportQueued:              "Export queued; the download link will be emailed when it is ready",
+		MsgImportCompleted:           "Testimonials imported",
+		MsgImportDryRun:              "Dry run complete; nothing was imported",
+	},
+	language.Spanish: {
+		MsgDataFetched:               "Datos obtenidos correctamente",
+		MsgTestimonialCreated:        "Testimonio creado correctamente",
+		MsgTestimonialFetched:        "Testimonio obtenido correctamente",
+		MsgTestimonialsFetched:       "Testimonios obtenidos correctamente",
+		MsgFeaturedFetched:           "Testimonios destacados obtenidos correctamente",
+		MsgTestimonialUpdated:        "Testimonio actualizado correctamente",
+		MsgTestimonialDeleted:        "Testimonio eliminado correctamente",
+		MsgTestimonialApproved:       "Testimonio aprobado correctamente",
+		MsgTestimonialFeatureUpdated: "Configuración de destacado actualizada",
+		MsgTestimonialReverted:       "Testimonio restablecido correctamente",
+		MsgTestimonialRestored:       "Testimonio recuperado correctamente",
+		MsgTestimonialPurged:         "Testimonio eliminado definitivamente",
+		MsgImageUploaded:             "Imagen subida correctamente",
+		MsgBulkApplied:               "Acción masiva %s aplicada: %d correctas, %d fallidas",
+		MsgTranslationSaved:          "Traducción guardada correctamente",
+		MsgTranslationDeleted:        "Traducción eliminada correctamente",
+		MsgMediaUploaded:             "Grabación subida y en cola para su procesamiento",
+		MsgMediaFetched:              "Archivos multimedia obtenidos correctamente",
+		MsgTranscriptUpdated:         "Transcripción actualizada correctamente",
+		MsgMediaApproved:             "Archivo multimedia aprobado correctamente",
+		MsgMediaDeleted:              "Archivo multimedia eliminado correctamente",
+		MsgReactionRecorded:          "Reacción registrada",
+		MsgReactionWithdrawn:         "Reacción retirada",
+		MsgCommentSubmitted:          "Comentario enviado para revisión",
+		MsgCommentApproved:           "Comentario aprobado correctamente",
+		MsgCommentRejected:           "Comentario rechazado correctamente",
+		MsgCommentDeleted:            "Comentario eliminado correctamente",
+		MsgExportQueued:              "Exportación en cola; el enlace de descarga se enviará por correo cuando esté lista",
+		MsgImportCompleted:           "Testimonios importados",
+		MsgImportDryRun:              "Simulación completada; no se importó nada",
+	},
+	language.French: {
+		MsgDataFetched:               "Données récupérées avec succès",
+		MsgTestimonialCreated:        "Témoignage créé avec succès",
+		MsgTestimonialFetched:        "Témoignage récupéré avec succès",
+		MsgTestimonialsFetched:       "Témoignages récupérés avec succès",
+		MsgFeaturedFetched:           "Témoignages à la une récupérés avec succès",
+		MsgTestimonialUpdated:        "Témoignage mis à jour avec succès",
+		MsgTestimonialDeleted:        "Témoignage supprimé avec succès",
+		MsgTestimonialApproved:       "Témoignage approuvé avec succès",
+		MsgTestimonialFeatureUpdated: "Paramètres de mise en avant mis à jour",
+		MsgTestimonialReverted:       "Témoignage rétabli avec succès",
+		MsgTestimonialRestored:       "Témoignage restauré avec succès",
+		MsgTestimonialPurged:         "Témoignage supprimé définitivement",
+		MsgImageUploaded:             "Image téléversée avec succès",
+		MsgBulkApplied:               "Action groupée %s appliquée : %d réussies, %d échouées",
+		MsgTranslationSaved:          "Traduction enregistrée avec succès",
+		MsgTranslationDeleted:        "Traduction supprimée avec succès",
+		MsgMediaUploaded:             "Enregistrement téléversé et mis en file d'attente",
+		MsgMediaFetched:              "Médias récupérés avec succès",
+		MsgTranscriptUpdated:         "Transcription mise à jour avec succès",
+		MsgMediaApproved:             "Média approuvé avec succès",
+		MsgMediaDeleted:              "Média supprimé avec succès",
+		MsgReactionRecorded:          "Réaction enregistrée",
+		MsgReactionWithdrawn:         "Réaction retirée",
+		MsgCommentSubmitted:          "Commentaire soumis pour modération",
+		MsgCommentApproved:           "Commentaire approuvé avec succès",
+		MsgCommentRejected:           "Commentaire rejeté avec succès",
+		MsgCommentDeleted:            "Commentaire supprimé avec succès",
+		MsgExportQueued:              "Export en file d'attente ; le lien de téléchargement sera envoyé par e-mail dès qu'il sera prêt",
+		MsgImportCompleted:           "Témoignages importés",
+		MsgImportDryRun:              "Simulation terminée ; rien n'a été importé",
+	},
+	language.Portuguese: {
+		MsgDataFetched:               "Dados obtidos com sucesso",
+		MsgTestimonialCreated:        "Testemunho criado com sucesso",
+		MsgTestimonialFetched:        "Testemunho obtido com sucesso",
+		MsgTestimonialsFetched:       "Testemunhos obtidos com sucesso",
+		MsgFeaturedFetched:           "Testemunhos em destaque obtidos com sucesso",
+		MsgTestimonialUpdated:        "Testemunho atualizado com sucesso",
+		MsgTestimonialDeleted:        "Testemunho excluído com sucesso",
+		MsgTestimonialApproved:       "Testemunho aprovado com sucesso",
+		MsgTestimonialFeatureUpdated: "Configurações de destaque atualizadas",
+		MsgTestimonialReverted:       "Testemunho revertido com sucesso",
+		MsgTestimonialRestored:       "Testemunho restaurado com sucesso",
+		MsgTestimonialPurged:         "Testemunho excluído permanentemente",
+		MsgImageUploaded:             "Imagem enviada com sucesso",
+		MsgBulkApplied:               "Ação em massa %s aplicada: %d com sucesso, %d com falha",
+		MsgTranslationSaved:          "Tradução salva com sucesso",
+		MsgTranslationDeleted:        "Tradução excluída com sucesso",
+		MsgMediaUploaded:             "Gravação enviada e na fila para processamento",
+		MsgMediaFetched:              "Mídias obtidas com sucesso",
+		MsgTranscriptUpdated:         "Transcrição atualizada com sucesso",
+		MsgMediaApproved:             "Mídia aprovada com sucesso",
+		MsgMediaDeleted:              "Mídia excluída com sucesso",
+		MsgReactionRecorded:          "Reação registrada",
+		MsgReactionWithdrawn:         "Reação retirada",
+		MsgCommentSubmitted:          "Comentário enviado para revisão",
+		MsgCommentApproved:           "Comentário aprovado com sucesso",
+		MsgCommentRejected:           "Comentário rejeitado com sucesso",
+		MsgCommentDeleted:            "Comentário excluído com sucesso",
+		MsgExportQueued:              "Exportação na fila; o link de download será enviado por e-mail quando estiver pronto",
+		MsgImportCompleted:           "Testemunhos importados",
+		MsgImportDryRun:              "Simulação concluída; nada foi importado",
+	},
+}
+
+// MessageLanguage returns the language messages are rendered in for the
+// caller carried by ctx.
+func MessageLanguage(ctx context.Context) language.Tag {
+	_, index, confidence := messageMatcher.Match(Preferences(ctx)...)
+	if confidence == language.No {
+		return messageLanguages[0]
+	}
+	return messageLanguages[index]
+}
+
+// Translate renders the message for key in the caller's language, formatting
+// args into it. Keys missing from that language fall back to English, and
+// text that is not a known key is returned unchanged.
+func Translate(ctx context.Context, key string, args ...any) string {
+	message, ok := catalogs[MessageLanguage(ctx)][key]
+	if !ok {
+		if message, ok = catalogs[messageLanguages[0]][key]; !ok {
+			return key
+		}
+	}
+	if len(args) > 0 {
+		return fmt.Sprintf(message, args...)
+	}
+	return message
+}
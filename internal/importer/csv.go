@@ -0,0 +1,163 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"html"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"wisdomHouse-backend/internal/models"
+	"wisdomHouse-backend/pkg/utils"
+)
+
+// requiredColumns must appear in the header row of a CSV import.
+var requiredColumns = []string{"first_name", "last_name", "testimony"}
+
+// paragraphBreak separates paragraphs in plain-text testimonies.
+var paragraphBreak = regexp.MustCompile(`\r?\n\s*\r?\n`)
+
+// dateLayouts are the date formats accepted in the created_at column.
+var dateLayouts = []string{time.RFC3339, "2006-01-02 15:04:05", time.DateOnly}
+
+// decodeCSV reads a CSV file with a header row. Columns are matched by name
+// ignoring case, spaces and underscores, so "First Name" and "first_name"
+// both work; unknown columns such as the export's id are ignored. Testimonies
+// are plain text with paragraphs separated by blank lines.
+func decodeCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, invalidFile("the file is empty")
+	}
+	if err != nil {
+		return nil, invalidFile("%v", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[columnKey(name)] = i
+	}
+	for _, name := range requiredColumns {
+		if _, ok := columns[columnKey(name)]; !ok {
+			return nil, invalidFile("missing required column %q", name)
+		}
+	}
+
+	var records []Record
+	for {
+		fields, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, invalidFile("%v", err)
+		}
+		if len(records) == models.MaxImportRows {
+			return nil, tooManyRows()
+		}
+		records = append(records, csvRecord(columns, fields))
+	}
+}
+
+func csvRecord(columns map[string]int, fields []string) Record {
+	var record Record
+	value := func(column string) string {
+		i, ok := columns[columnKey(column)]
+		if !ok || i >= len(fields) {
+			return ""
+		}
+		return restoreCell(strings.TrimSpace(fields[i]))
+	}
+	fail := func(column, rule, message string) {
+		record.Errors = append(record.Errors, utils.FieldError{Field: column, Rule: rule, Message: message})
+	}
+
+	record.Row.FirstName = value("first_name")
+	record.Row.LastName = value("last_name")
+	record.Row.Testimony = plainTextToHTML(value("testimony"))
+	record.Row.Language = value("language")
+	if url := value("image_url"); url != "" {
+		record.Row.ImageURL = &url
+	}
+
+	var ok bool
+	if record.Row.IsAnonymous, ok = parseBool(value("is_anonymous")); !ok {
+		fail("is_anonymous", "boolean", "must be true or false")
+	}
+	if record.Row.IsApproved, ok = parseBool(value("is_approved")); !ok {
+		fail("is_approved", "boolean", "must be true or false")
+	}
+
+	for _, tag := range strings.FieldsFunc(value("tags"), func(r rune) bool { return r == ';' || r == ',' }) {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			record.Row.Tags = append(record.Row.Tags, tag)
+		}
+	}
+
+	if created := value("created_at"); created != "" {
+		if record.Row.CreatedAt, ok = parseDate(created); !ok {
+			fail("created_at", "datetime", "must be a date (YYYY-MM-DD) or RFC 3339 timestamp")
+		}
+	}
+	return record
+}
+
+// columnKey normalizes a header name for matching.
+func columnKey(name string) string {
+	name = strings.TrimPrefix(name, "\uFEFF") // Byte order mark written by spreadsheet programs
+	return strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '_', '-':
+			return -1
+		}
+		return r
+	}, strings.ToLower(strings.TrimSpace(name)))
+}
+
+// restoreCell undoes the quote the CSV export adds in front of text a
+// spreadsheet would evaluate as a formula.
+func restoreCell(value string) string {
+	if len(value) > 1 && value[0] == '\'' && strings.ContainsRune("=+-@", rune(value[1])) {
+		return value[1:]
+	}
+	return value
+}
+
+// plainTextToHTML turns a plain-text testimony into paragraphs.
+func plainTextToHTML(text string) string {
+	var out strings.Builder
+	for _, paragraph := range paragraphBreak.Split(text, -1) {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			out.WriteString("<p>" + html.EscapeString(paragraph) + "</p>")
+		}
+	}
+	return out.String()
+}
+
+// parseBool accepts the spellings spreadsheets commonly use; blank is false.
+func parseBool(value string) (bool, bool) {
+	switch strings.ToLower(value) {
+	case "", "no", "n":
+		return false, true
+	case "yes", "y":
+		return true, true
+	}
+	b, err := strconv.ParseBool(value)
+	return b, err == nil
+}
+
+func parseDate(value string) (*time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return &t, true
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,42 @@
+package importer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"wisdomHouse-backend/internal/models"
+	"wisdomHouse-backend/pkg/utils"
+)
+
+// ErrInvalidFile wraps failures that make a whole file unreadable, as
+// opposed to problems confined to single rows.
+var ErrInvalidFile = errors.New("invalid import file")
+
+// Record is one decoded row. Errors lists the fields whose values could not
+// be decoded; such rows are reported as invalid without further checks.
+type Record struct {
+	Row    models.ImportRow
+	Errors []utils.FieldError
+}
+
+// Decode reads every row of an import file. It fails with ErrInvalidFile if
+// the file is malformed or holds more than models.MaxImportRows rows.
+func Decode(format models.ImportFormat, r io.Reader) ([]Record, error) {
+	switch format {
+	case models.ImportCSV:
+		return decodeCSV(r)
+	case models.ImportJSON:
+		return decodeJSON(r)
+	default:
+		return nil, fmt.Errorf("%w: unsupported format %q", ErrInvalidFile, format)
+	}
+}
+
+func invalidFile(format string, args ...any) error {
+	return fmt.Errorf("%w: %s", ErrInvalidFile, fmt.Sprintf(format, args...))
+}
+
+func tooManyRows() error {
+	return invalidFile("more than %d rows; split the file and import each part", models.MaxImportRows)
+}
@@ -0,0 +1,99 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"wisdomHouse-backend/internal/models"
+	"wisdomHouse-backend/pkg/utils"
+)
+
+// decodeJSON reads either an array of rows or newline-delimited rows, such
+// as the NDJSON export. Testimonies are rich text, as stored by the API.
+func decodeJSON(r io.Reader) ([]Record, error) {
+	buffered := bufio.NewReader(r)
+	first, err := firstByte(buffered)
+	if errors.Is(err, io.EOF) {
+		return nil, invalidFile("the file is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(buffered)
+	array := first == '['
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return nil, invalidFile("%v", err)
+		}
+	}
+
+	var records []Record
+	for {
+		if array && !dec.More() {
+			break
+		}
+		var raw json.RawMessage
+		err := dec.Decode(&raw)
+		if !array && errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, invalidFile("row %d: %v", len(records)+1, err)
+		}
+		if len(records) == models.MaxImportRows {
+			return nil, tooManyRows()
+		}
+		records = append(records, jsonRecord(raw))
+	}
+
+	if array {
+		if _, err := dec.Token(); err != nil {
+			return nil, invalidFile("%v", err)
+		}
+	}
+	return records, nil
+}
+
+func jsonRecord(raw json.RawMessage) Record {
+	var record Record
+	if !bytes.HasPrefix(bytes.TrimSpace(raw), []byte("{")) {
+		record.Errors = append(record.Errors, utils.FieldError{Field: "row", Rule: "type", Message: "must be an object"})
+		return record
+	}
+	if err := json.Unmarshal(raw, &record.Row); err != nil {
+		record.Errors = append(record.Errors, jsonFieldError(err))
+	}
+	return record
+}
+
+func jsonFieldError(err error) utils.FieldError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return utils.FieldError{
+			Field:   typeErr.Field,
+			Rule:    "type",
+			Message: fmt.Sprintf("must be a %s", typeErr.Type.String()),
+		}
+	}
+	return utils.FieldError{Field: "row", Rule: "type", Message: err.Error()}
+}
+
+// firstByte peeks at the first non-whitespace byte without consuming it.
+func firstByte(r *bufio.Reader) (byte, error) {
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, r.UnreadByte()
+	}
+}
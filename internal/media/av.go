@@ -0,0 +1,151 @@
+package media
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"wisdomHouse-backend/internal/models"
+)
+
+var (
+	ErrMediaTooLarge        = errors.New("media exceeds the maximum allowed size")
+	ErrUnsupportedMediaType = errors.New("unsupported media type")
+)
+
+// mediaTypes maps sniffed MIME types to their kind and file extension.
+var mediaTypes = map[string]struct {
+	kind      models.MediaKind
+	extension string
+}{
+	"video/mp4":       {models.MediaKindVideo, ".mp4"},
+	"video/webm":      {models.MediaKindVideo, ".webm"},
+	"video/avi":       {models.MediaKindVideo, ".avi"},
+	"audio/mpeg":      {models.MediaKindAudio, ".mp3"},
+	"audio/wave":      {models.MediaKindAudio, ".wav"},
+	"audio/aiff":      {models.MediaKindAudio, ".aiff"},
+	"audio/ogg":       {models.MediaKindAudio, ".ogg"},
+	"application/ogg": {models.MediaKindAudio, ".ogg"},
+}
+
+// DetectMediaType sniffs the leading bytes of r and reports the MIME type,
+// kind and canonical file extension of a supported video or audio file.
+func DetectMediaType(r io.Reader) (string, models.MediaKind, string, error) {
+	head := make([]byte, 512)
+	n, err := io.ReadFull(r, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return "", "", "", fmt.Errorf("%w: %v", ErrUnsupportedMediaType, err)
+	}
+
+	contentType := http.DetectContentType(head[:n])
+	info, ok := mediaTypes[contentType]
+	if !ok {
+		return "", "", "", fmt.Errorf("%w: %s", ErrUnsupportedMediaType, contentType)
+	}
+	return contentType, info.kind, info.extension, nil
+}
+
+// TranscodeResult describes a web-friendly rendition written to a local file.
+type TranscodeResult struct {
+	Path        string
+	ContentType string
+	Extension   string
+}
+
+// Transcoder inspects and converts uploaded recordings.
+type Transcoder interface {
+	// Probe returns the duration of the recording at path in seconds.
+	Probe(ctx context.Context, path string) (float64, error)
+	// Transcode converts the recording at path into a format every browser
+	// can play. The caller removes the result file when done with it.
+	Transcode(ctx context.Context, path string, kind models.MediaKind) (*TranscodeResult, error)
+}
+
+// FFmpegTranscoder shells out to the ffprobe and ffmpeg binaries.
+type FFmpegTranscoder struct {
+	ffmpegPath  string
+	ffprobePath string
+	tempDir     string
+}
+
+func NewFFmpegTranscoder(ffmpegPath, ffprobePath, tempDir string) *FFmpegTranscoder {
+	return &FFmpegTranscoder{
+		ffmpegPath:  ffmpegPath,
+		ffprobePath: ffprobePath,
+		tempDir:     tempDir,
+	}
+}
+
+func (t *FFmpegTranscoder) Probe(ctx context.Context, path string) (float64, error) {
+	cmd := exec.CommandContext(ctx, t.ffprobePath,
+		"-v", "error",
+		"-show_entries", "format=duration",
+		"-of", "json",
+		path,
+	)
+	output, err := cmd.Output()
+	if err != nil {
+		return 0, fmt.Errorf("ffprobe failed: %w", err)
+	}
+
+	var probe struct {
+		Format struct {
+			Duration string `json:"duration"`
+		} `json:"format"`
+	}
+	if err := json.Unmarshal(output, &probe); err != nil {
+		return 0, fmt.Errorf("failed to parse ffprobe output: %w", err)
+	}
+
+	duration, err := strconv.ParseFloat(probe.Format.Duration, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: %w", probe.Format.Duration, err)
+	}
+	return duration, nil
+}
+
+func (t *FFmpegTranscoder) Transcode(ctx context.Context, path string, kind models.MediaKind) (*TranscodeResult, error) {
+	result := &TranscodeResult{ContentType: "video/mp4", Extension: ".mp4"}
+	codecArgs := []string{"-c:v", "libx264", "-preset", "veryfast", "-crf", "23", "-c:a", "aac", "-b:a", "128k", "-movflags", "+faststart"}
+	if kind == models.MediaKindAudio {
+		result.ContentType, result.Extension = "audio/mp4", ".m4a"
+		codecArgs = []string{"-vn", "-c:a", "aac", "-b:a", "128k", "-movflags", "+faststart"}
+	}
+
+	out, err := os.CreateTemp(t.tempDir, "transcode-*"+result.Extension)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	out.Close()
+	result.Path = out.Name()
+
+	// -map_metadata -1 drops container metadata such as recording location
+	args := append([]string{"-y", "-i", path, "-map_metadata", "-1"}, codecArgs...)
+	args = append(args, result.Path)
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, t.ffmpegPath, args...)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		os.Remove(result.Path)
+		return nil, fmt.Errorf("ffmpeg failed: %w: %s", err, lastLine(stderr.String()))
+	}
+
+	return result, nil
+}
+
+func lastLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.LastIndex(s, "\n"); i >= 0 {
+		return s[i+1:]
+	}
+	return s
+}
@@ -0,0 +1,157 @@
+package media
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	_ "image/gif" // Register GIF decoder
+)
+
+var (
+	ErrImageTooLarge        = errors.New("image exceeds the maximum allowed size")
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+	ErrInvalidImage         = errors.New("image could not be decoded")
+)
+
+// maxImagePixels guards against decompression bombs: a small file that
+// decodes into an enormous bitmap.
+const maxImagePixels = 40_000_000
+
+// ImageVariant describes one resized rendition of an uploaded image.
+type ImageVariant struct {
+	Name         string
+	MaxDimension int
+}
+
+// StandardImageVariants are the renditions stored for every testimonial photo.
+var StandardImageVariants = []ImageVariant{
+	{Name: "thumb", MaxDimension: 150},
+	{Name: "display", MaxDimension: 1200},
+}
+
+// ProcessedImage is a re-encoded image ready to be written to storage.
+type ProcessedImage struct {
+	Variant     string
+	Data        []byte
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+}
+
+// allowedImageTypes maps sniffed MIME types to whether they are accepted.
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+// DetectImageType sniffs the content type from the file's leading bytes,
+// ignoring whatever the client claimed in its headers.
+func DetectImageType(data []byte) (string, error) {
+	contentType := http.DetectContentType(data)
+	if !allowedImageTypes[contentType] {
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedImageType, contentType)
+	}
+	return contentType, nil
+}
+
+// ProcessImage validates an uploaded image and renders each variant. Images
+// are fully decoded and re-encoded, which strips EXIF and any other metadata.
+// PNGs stay PNG to keep transparency; everything else becomes JPEG.
+func ProcessImage(data []byte, maxBytes int64, variants []ImageVariant) ([]ProcessedImage, error) {
+	if maxBytes > 0 && int64(len(data)) > maxBytes {
+		return nil, ErrImageTooLarge
+	}
+
+	contentType, err := DetectImageType(data)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+	if cfg.Width*cfg.Height > maxImagePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImage, err)
+	}
+
+	processed := make([]ProcessedImage, 0, len(variants))
+	for _, variant := range variants {
+		resized := resize(src, variant.MaxDimension)
+
+		var buf bytes.Buffer
+		out := ProcessedImage{
+			Variant: variant.Name,
+			Width:   resized.Bounds().Dx(),
+			Height:  resized.Bounds().Dy(),
+		}
+		if contentType == "image/png" {
+			err = png.Encode(&buf, resized)
+			out.ContentType, out.Extension = "image/png", ".png"
+		} else {
+			err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: 85})
+			out.ContentType, out.Extension = "image/jpeg", ".jpg"
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode %s variant: %w", variant.Name, err)
+		}
+		out.Data = buf.Bytes()
+		processed = append(processed, out)
+	}
+
+	return processed, nil
+}
+
+// resize scales src down so neither side exceeds maxDim, averaging the source
+// pixels covered by each destination pixel. Images are never scaled up.
+func resize(src image.Image, maxDim int) image.Image {
+	bounds := src.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if maxDim <= 0 || (w <= maxDim && h <= maxDim) {
+		return src
+	}
+
+	dw, dh := maxDim, maxDim
+	if w >= h {
+		dh = max(1, h*maxDim/w)
+	} else {
+		dw = max(1, w*maxDim/h)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < dh; y++ {
+		sy0, sy1 := y*h/dh, max((y+1)*h/dh, y*h/dh+1)
+		for x := 0; x < dw; x++ {
+			sx0, sx1 := x*w/dw, max((x+1)*w/dw, x*w/dw+1)
+
+			var r, g, b, a, n uint64
+			for sy := sy0; sy < sy1; sy++ {
+				for sx := sx0; sx < sx1; sx++ {
+					cr, cg, cb, ca := src.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					r, g, b, a = r+uint64(cr), g+uint64(cg), b+uint64(cb), a+uint64(ca)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: uint8(a / n >> 8),
+			})
+		}
+	}
+	return dst
+}
@@ -0,0 +1,30 @@
+package middleware
+
+import (
+    "regexp"
+
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/audit"
+)
+
+const ActorHeader = "X-Actor"
+
+// validActor limits the actor to a short name or email so it is safe to
+// store and log.
+var validActor = regexp.MustCompile(`^[A-Za-z0-9._@+ -]{1,100}$`)
+
+// Actor records who is making the request, from the X-Actor header, so
+// changes can be attributed in the revision history. Until authentication
+// is in place the header is trusted as given; requests without a valid one
+// are attributed to "anonymous".
+func Actor() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        actor := c.GetHeader(ActorHeader)
+        if !validActor.MatchString(actor) {
+            actor = "anonymous"
+        }
+        
+        c.Request = c.Request.WithContext(audit.WithActor(c.Request.Context(), actor))
+        c.Next()
+    }
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+    "fmt"
+    "log/slog"
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+// ErrorHandler renders the last error a handler attached with c.Error as an
+// RFC 7807 problem. Handlers report failures by calling c.Error and returning.
+func ErrorHandler() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        c.Next()
+        
+        if len(c.Errors) == 0 || c.Writer.Written() {
+            return
+        }
+        
+        writeProblem(c, apperrors.As(c.Errors.Last().Err))
+    }
+}
+
+// Recovery turns panics into a 500 problem response instead of an empty body.
+func Recovery() gin.HandlerFunc {
+    return gin.CustomRecovery(func(c *gin.Context, recovered any) {
+        writeProblem(c, apperrors.Internal(fmt.Errorf("panic: %v", recovered)))
+    })
+}
+
+// NoRoute answers unknown paths with a problem response.
+func NoRoute(c *gin.Context) {
+    writeProblem(c, apperrors.NotFound(apperrors.CodeRouteNotFound, "No route matches "+c.Request.Method+" "+c.Request.URL.Path))
+}
+
+func writeProblem(c *gin.Context, err *apperrors.Error) {
+    requestID := GetRequestID(c)
+    status := err.Kind.Status()
+    
+    if status >= http.StatusInternalServerError {
+        slog.ErrorContext(c.Request.Context(), "request failed",
+            "method", c.Request.Method,
+            "path", c.Request.URL.Path,
+            "code", err.Code,
+            "error", err,
+        )
+    }
+    
+    utils.ProblemResponse(c, utils.Problem{
+        Status:    status,
+        Detail:    err.Message,
+        Instance:  c.Request.URL.Path,
+        Code:      err.Code,
+        RequestID: requestID,
+        Errors:    err.Fields,
+    })
+}
@@ -0,0 +1,20 @@
+package middleware
+
+import (
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/i18n"
+)
+
+// Locale reads the caller's Accept-Language into the request context, where
+// response messages and testimonial translations are chosen from it.
+func Locale() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        prefs := i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language"))
+        c.Request = c.Request.WithContext(i18n.WithPreferences(c.Request.Context(), prefs))
+        
+        // Responses differ by language, so shared caches must key on it
+        c.Writer.Header().Add("Vary", "Accept-Language")
+        
+        c.Next()
+    }
+}
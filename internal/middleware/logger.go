@@ -1,33 +1,65 @@
 package middleware
 
 import (
-    "log"
+    "log/slog"
+    "net/url"
     "time"
 
     "github.com/gin-gonic/gin"
 )
 
+// Logger writes one structured record per request. Server errors are logged
+// at error level and client errors at warn so they stand out from traffic.
 func Logger() gin.HandlerFunc {
     return func(c *gin.Context) {
         start := time.Now()
         path := c.Request.URL.Path
-        raw := c.Request.URL.RawQuery
+        raw := redactQuery(c.Request.URL.RawQuery)
 
         c.Next()
 
-        timestamp := time.Now()
-        latency := timestamp.Sub(start)
+        latency := time.Since(start)
+        status := c.Writer.Status()
 
-        if raw != "" {
-            path = path + "?" + raw
+        level := slog.LevelInfo
+        switch {
+        case status >= 500:
+            level = slog.LevelError
+        case status >= 400:
+            level = slog.LevelWarn
         }
 
-        log.Printf("[%s] %s %s %d %s",
-            c.Request.Method,
-            path,
-            c.ClientIP(),
-            c.Writer.Status(),
-            latency,
+        slog.Log(c.Request.Context(), level, "request",
+            "method", c.Request.Method,
+            "path", path,
+            "query", raw,
+            "route", c.FullPath(),
+            "status", status,
+            "latency", latency,
+            "client_ip", c.ClientIP(),
+            "bytes", c.Writer.Size(),
         )
     }
+}
+
+// redactedParams are query parameters that grant access, such as the
+// signature on a download link, and must not end up in logs.
+var redactedParams = []string{"signature", "expires"}
+
+// redactQuery masks the values of redactedParams. A query that cannot be
+// parsed is dropped rather than logged as is.
+func redactQuery(raw string) string {
+    if raw == "" {
+        return ""
+    }
+    values, err := url.ParseQuery(raw)
+    if err != nil {
+        return "[unparsable]"
+    }
+    for _, name := range redactedParams {
+        if values.Has(name) {
+            values.Set(name, "REDACTED")
+        }
+    }
+    return values.Encode()
 }
\ No newline at end of file
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+    "crypto/subtle"
+    "net/http"
+    "strconv"
+    "strings"
+    "time"
+
+    "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/metrics"
+)
+
+// unmatchedRoute labels requests that hit no route, so scanners probing
+// random paths cannot blow up the metric cardinality.
+const unmatchedRoute = "unmatched"
+
+// Metrics records request counts and latency by route template and status.
+func Metrics() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        start := time.Now()
+
+        c.Next()
+
+        route := c.FullPath()
+        if route == "" {
+            route = unmatchedRoute
+        }
+        status := strconv.Itoa(c.Writer.Status())
+
+        metrics.HTTPRequests.WithLabelValues(c.Request.Method, route, status).Inc()
+        metrics.HTTPRequestDuration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+    }
+}
+
+// MetricsAuth requires the given bearer token. An empty token leaves the
+// endpoint open, which is only appropriate on a private admin port.
+func MetricsAuth(token string) gin.HandlerFunc {
+    return func(c *gin.Context) {
+        if token == "" {
+            c.Next()
+            return
+        }
+
+        provided := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+        if subtle.ConstantTimeCompare([]byte(provided), []byte(token)) != 1 {
+            c.Header("WWW-Authenticate", `Bearer realm="metrics"`)
+            c.AbortWithStatus(http.StatusUnauthorized)
+            return
+        }
+        c.Next()
+    }
+}
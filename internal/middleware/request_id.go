@@ -0,0 +1,43 @@
+package middleware
+
+import (
+    "regexp"
+
+    "github.com/gin-gonic/gin"
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/logging"
+)
+
+const (
+    RequestIDHeader = "X-Request-ID"
+    RequestIDKey    = "requestID"
+)
+
+// validRequestID limits client-supplied IDs to a safe length and alphabet so
+// they can't be used to inject content into logs.
+var validRequestID = regexp.MustCompile(`^[A-Za-z0-9._:-]{1,128}$`)
+
+// RequestID accepts the caller's X-Request-ID or generates one, stores it on
+// the gin and request contexts and echoes it on the response.
+func RequestID() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        id := c.GetHeader(RequestIDHeader)
+        if !validRequestID.MatchString(id) {
+            id = uuid.New().String()
+        }
+        
+        c.Set(RequestIDKey, id)
+        c.Request = c.Request.WithContext(logging.WithRequestID(c.Request.Context(), id))
+        c.Writer.Header().Set(RequestIDHeader, id)
+        
+        c.Next()
+    }
+}
+
+// GetRequestID returns the correlation ID assigned to the request.
+func GetRequestID(c *gin.Context) string {
+    if id := c.GetString(RequestIDKey); id != "" {
+        return id
+    }
+    return logging.RequestID(c.Request.Context())
+}
\ No newline at end of file
@@ -0,0 +1,50 @@
+package middleware
+
+import (
+    "net/http"
+
+    "github.com/gin-gonic/gin"
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/attribute"
+    "go.opentelemetry.io/otel/codes"
+    "go.opentelemetry.io/otel/propagation"
+    semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+    "go.opentelemetry.io/otel/trace"
+    "wisdomHouse-backend/internal/tracing"
+)
+
+// Tracing starts a server span per request, continuing any trace passed in
+// by the caller via W3C traceparent headers. The span is named after the
+// route template once routing has matched.
+func Tracing() gin.HandlerFunc {
+    return func(c *gin.Context) {
+        ctx := otel.GetTextMapPropagator().Extract(c.Request.Context(), propagation.HeaderCarrier(c.Request.Header))
+        ctx, span := tracing.Tracer().Start(ctx, c.Request.Method,
+            trace.WithSpanKind(trace.SpanKindServer),
+            trace.WithAttributes(
+                semconv.HTTPRequestMethodKey.String(c.Request.Method),
+                semconv.URLPath(c.Request.URL.Path),
+                semconv.ClientAddress(c.ClientIP()),
+                semconv.UserAgentOriginal(c.Request.UserAgent()),
+                attribute.String("request.id", GetRequestID(c)),
+            ),
+        )
+        defer span.End()
+
+        c.Request = c.Request.WithContext(ctx)
+        c.Next()
+
+        status := c.Writer.Status()
+        span.SetAttributes(semconv.HTTPResponseStatusCode(status))
+        if route := c.FullPath(); route != "" {
+            span.SetName(c.Request.Method + " " + route)
+            span.SetAttributes(semconv.HTTPRoute(route))
+        }
+        if status >= http.StatusInternalServerError {
+            span.SetStatus(codes.Error, http.StatusText(status))
+        }
+        for _, err := range c.Errors {
+            span.RecordError(err.Err)
+        }
+    }
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Comment is a visitor's encouragement under a testimony. Comments go through
+// the same moderation as testimonials and are threaded one level deep:
+// replies always point at a top-level comment.
+type Comment struct {
+	ID            uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	TestimonialID uuid.UUID      `json:"testimonialId" gorm:"column:testimonial_id;type:uuid;not null;index"`
+	ParentID      *uuid.UUID     `json:"parentId,omitempty" gorm:"column:parent_id;type:uuid;index"`
+	AuthorName    string         `json:"authorName" gorm:"column:author_name;type:varchar(100);not null"`
+	AuthorEmail   *string        `json:"authorEmail,omitempty" gorm:"column:author_email;type:varchar(254)"` // Only for notifications, never shown publicly
+	Body          string         `json:"body" gorm:"column:body;type:text;not null"`
+	IsApproved    bool           `json:"isApproved" gorm:"column:is_approved;not null;default:false"`
+	IsFlagged     bool           `json:"isFlagged" gorm:"column:is_flagged;not null;default:false"`
+	FlagReasons   *string        `json:"flagReasons,omitempty" gorm:"column:flag_reasons;type:text"`
+	RejectedAt    *time.Time     `json:"rejectedAt,omitempty" gorm:"column:rejected_at"`
+	CreatedAt     time.Time      `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time      `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Replies []Comment `json:"replies,omitempty" gorm:"foreignKey:ParentID"`
+}
+
+func (Comment) TableName() string {
+	return "testimonial_comments"
+}
+
+type CreateCommentRequest struct {
+	AuthorName   string     `json:"authorName" binding:"required,notblank,max=100,personname"`
+	AuthorEmail  *string    `json:"authorEmail,omitempty" binding:"omitempty,email,max=254"`
+	Body         string     `json:"body" binding:"required,notblank,max=5000"`
+	ParentID     *uuid.UUID `json:"parentId,omitempty"`
+	Website      string     `json:"website"` // Honeypot: hidden from humans, left empty by real visitors
+	CaptchaToken string     `json:"captchaToken" binding:"max=4096"`
+	ClientIP     string     `json:"-"`
+}
+
+// PublicComment is a comment as shown under a testimony, with its approved
+// replies.
+type PublicComment struct {
+	ID         uuid.UUID       `json:"id"`
+	ParentID   *uuid.UUID      `json:"parentId,omitempty"`
+	AuthorName string          `json:"authorName"`
+	Body       string          `json:"body"`
+	CreatedAt  time.Time       `json:"createdAt"`
+	Replies    []PublicComment `json:"replies,omitempty"`
+}
+
+// ToPublic converts the comment and its loaded replies.
+func (c *Comment) ToPublic() PublicComment {
+	public := PublicComment{
+		ID:         c.ID,
+		ParentID:   c.ParentID,
+		AuthorName: c.AuthorName,
+		Body:       c.Body,
+		CreatedAt:  c.CreatedAt,
+	}
+	for i := range c.Replies {
+		public.Replies = append(public.Replies, c.Replies[i].ToPublic())
+	}
+	return public
+}
+
+// CommentFilter narrows moderator comment listings.
+type CommentFilter struct {
+	TestimonialID *uuid.UUID
+	Status        CommentStatus
+}
+
+// CommentStatus selects comments by moderation state.
+type CommentStatus string
+
+const (
+	CommentStatusAll      CommentStatus = ""
+	CommentStatusPending  CommentStatus = "pending" // Neither approved nor rejected
+	CommentStatusFlagged  CommentStatus = "flagged"
+	CommentStatusApproved CommentStatus = "approved"
+	CommentStatusRejected CommentStatus = "rejected"
+)
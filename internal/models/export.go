@@ -0,0 +1,75 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ExportFormat selects how exported testimonials are encoded.
+type ExportFormat string
+
+const (
+	ExportCSV    ExportFormat = "csv"
+	ExportNDJSON ExportFormat = "ndjson" // One AdminTestimonial JSON object per line
+	ExportPDF    ExportFormat = "pdf"    // Printable booklet honouring IsAnonymous
+)
+
+func (f ExportFormat) Valid() bool {
+	switch f {
+	case ExportCSV, ExportNDJSON, ExportPDF:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportCSV:
+		return "text/csv; charset=utf-8"
+	case ExportNDJSON:
+		return "application/x-ndjson"
+	case ExportPDF:
+		return "application/pdf"
+	default:
+		return "application/octet-stream"
+	}
+}
+
+// FileName is the download name for an export created at the given time.
+func (f ExportFormat) FileName(at time.Time) string {
+	return "testimonials-" + at.UTC().Format("20060102-150405") + "." + string(f)
+}
+
+// ExportRequest queues an export to be built in the background; the download
+// link is emailed once it is ready.
+type ExportRequest struct {
+	Format   ExportFormat `json:"format" binding:"required,oneof=csv ndjson pdf"`
+	Email    string       `json:"email" binding:"required,email,max=254"`
+	Title    string       `json:"title" binding:"max=200"` // Booklet cover title
+	Approved *bool        `json:"approved,omitempty"`      // Defaults to approved testimonials only
+	Tag      string       `json:"tag" binding:"max=50"`
+	Search   string       `json:"q" binding:"max=200"`
+	From     *time.Time   `json:"from,omitempty"` // Created at or after
+	To       *time.Time   `json:"to,omitempty"`   // Created before
+}
+
+// Filter selects the testimonials the request covers.
+func (r *ExportRequest) Filter() TestimonialFilter {
+	return TestimonialFilter{
+		ApprovedOnly: r.Approved == nil || *r.Approved,
+		Tag:          r.Tag,
+		Search:       r.Search,
+		From:         r.From,
+		To:           r.To,
+	}
+}
+
+// ExportJob acknowledges a queued export.
+type ExportJob struct {
+	ID       uuid.UUID    `json:"id"`
+	Format   ExportFormat `json:"format"`
+	Email    string       `json:"email"`
+	QueuedAt time.Time    `json:"queuedAt"`
+}
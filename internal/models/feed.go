@@ -0,0 +1,33 @@
+package models
+
+// FeedFormat selects how the public testimonial feed is syndicated. The value
+// doubles as the file extension in the feed's URL.
+type FeedFormat string
+
+const (
+	FeedRSS  FeedFormat = "rss"  // RSS 2.0
+	FeedAtom FeedFormat = "atom" // Atom 1.0
+	FeedJSON FeedFormat = "json" // JSON Feed 1.1
+)
+
+func (f FeedFormat) Valid() bool {
+	switch f {
+	case FeedRSS, FeedAtom, FeedJSON:
+		return true
+	default:
+		return false
+	}
+}
+
+func (f FeedFormat) ContentType() string {
+	switch f {
+	case FeedRSS:
+		return "application/rss+xml; charset=utf-8"
+	case FeedAtom:
+		return "application/atom+xml; charset=utf-8"
+	case FeedJSON:
+		return "application/feed+json; charset=utf-8"
+	default:
+		return "application/octet-stream"
+	}
+}
@@ -0,0 +1,90 @@
+package models
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"wisdomHouse-backend/pkg/utils"
+)
+
+// ImportFormat selects how an import file is decoded.
+type ImportFormat string
+
+const (
+	ImportCSV  ImportFormat = "csv"  // Header row naming the columns, as written by the CSV export
+	ImportJSON ImportFormat = "json" // An array of rows, or one row per line as written by the NDJSON export
+)
+
+// ImportFormatForFile infers the format from a file name's extension,
+// returning "" when it is not recognised.
+func ImportFormatForFile(name string) ImportFormat {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".csv":
+		return ImportCSV
+	case ".json", ".ndjson", ".jsonl":
+		return ImportJSON
+	default:
+		return ""
+	}
+}
+
+// MaxImportRows bounds a single import so it fits in one transaction.
+const MaxImportRows = 10000
+
+// ImportRow is one testimony from a legacy spreadsheet or an earlier export.
+// Its content is validated with the same rules as CreateTestimonialRequest;
+// Tags follow the bulk moderation limits.
+type ImportRow struct {
+	FirstName   string     `json:"firstName"`
+	LastName    string     `json:"lastName"`
+	ImageURL    *string    `json:"imageUrl,omitempty"`
+	Testimony   string     `json:"testimony"`
+	Language    string     `json:"language,omitempty"` // Defaults to English
+	IsAnonymous bool       `json:"isAnonymous"`
+	IsApproved  bool       `json:"isApproved"`
+	Tags        Tags       `json:"tags,omitempty" binding:"max=20,dive,notblank,max=50"`
+	CreatedAt   *time.Time `json:"createdAt,omitempty"` // Original submission date; defaults to the import time
+}
+
+// CreateRequest is the row as a public submission, for validation.
+func (r *ImportRow) CreateRequest() CreateTestimonialRequest {
+	return CreateTestimonialRequest{
+		FirstName:   r.FirstName,
+		LastName:    r.LastName,
+		ImageURL:    r.ImageURL,
+		Testimony:   r.Testimony,
+		Language:    r.Language,
+		IsAnonymous: r.IsAnonymous,
+	}
+}
+
+// ImportRowStatus is the outcome for one row of an import.
+type ImportRowStatus string
+
+const (
+	ImportCreated   ImportRowStatus = "created" // Inserted, or would be on a dry run
+	ImportDuplicate ImportRowStatus = "duplicate"
+	ImportInvalid   ImportRowStatus = "invalid"
+)
+
+// ImportRowResult reports one row. Row numbers start at 1 with the first
+// data row, so CSV header rows are not counted.
+type ImportRowResult struct {
+	Row     int                `json:"row"`
+	Status  ImportRowStatus    `json:"status"`
+	ID      *uuid.UUID         `json:"id,omitempty"` // Set once the row has been inserted
+	Message string             `json:"message,omitempty"`
+	Errors  []utils.FieldError `json:"errors,omitempty"`
+}
+
+// ImportResult summarizes an import; Rows follows the order of the file.
+type ImportResult struct {
+	DryRun     bool              `json:"dryRun"`
+	Total      int               `json:"total"`
+	Created    int               `json:"created"`
+	Duplicates int               `json:"duplicates"`
+	Invalid    int               `json:"invalid"`
+	Rows       []ImportRowResult `json:"rows"`
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// MediaKind distinguishes video from audio attachments.
+type MediaKind string
+
+const (
+	MediaKindVideo MediaKind = "video"
+	MediaKindAudio MediaKind = "audio"
+)
+
+// TranscodeStatus tracks the background transcoding of a media attachment.
+type TranscodeStatus string
+
+const (
+	TranscodePending    TranscodeStatus = "pending"
+	TranscodeProcessing TranscodeStatus = "processing"
+	TranscodeReady      TranscodeStatus = "ready"
+	TranscodeFailed     TranscodeStatus = "failed"
+)
+
+// TestimonialMedia is a video or audio recording attached to a testimonial.
+// The original upload is kept alongside a transcoded, web-friendly rendition.
+// Recordings are uploaded without authentication, so like testimonials they
+// stay hidden from the public until a moderator approves them.
+type TestimonialMedia struct {
+	ID              uuid.UUID       `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	TestimonialID   uuid.UUID       `json:"testimonialId" gorm:"column:testimonial_id;type:uuid;not null;index"`
+	Kind            MediaKind       `json:"kind" gorm:"column:kind;type:varchar(10);not null"`
+	MimeType        string          `json:"mimeType" gorm:"column:mime_type;type:varchar(100);not null"`
+	SizeBytes       int64           `json:"sizeBytes" gorm:"column:size_bytes;not null"`
+	DurationSeconds *float64        `json:"durationSeconds,omitempty" gorm:"column:duration_seconds"`
+	OriginalURL     string          `json:"originalUrl" gorm:"column:original_url;type:varchar(500);not null"`
+	TranscodedURL   *string         `json:"transcodedUrl,omitempty" gorm:"column:transcoded_url;type:varchar(500)"`
+	TranscodeStatus TranscodeStatus `json:"transcodeStatus" gorm:"column:transcode_status;type:varchar(20);not null;default:pending"`
+	TranscodeError  *string         `json:"transcodeError,omitempty" gorm:"column:transcode_error;type:text"`
+	Transcript      *string         `json:"transcript,omitempty" gorm:"column:transcript;type:text"`
+	IsApproved      bool            `json:"isApproved" gorm:"column:is_approved;not null;default:false"`
+	IsFlagged       bool            `json:"isFlagged" gorm:"column:is_flagged;not null;default:false"`
+	FlagReasons     *string         `json:"flagReasons,omitempty" gorm:"column:flag_reasons;type:text"`
+	CreatedAt       time.Time       `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt       time.Time       `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt       gorm.DeletedAt  `gorm:"index" json:"-"`
+}
+
+// PublicMedia is the representation of a media attachment served to the public.
+type PublicMedia struct {
+	ID              uuid.UUID `json:"id"`
+	Kind            MediaKind `json:"kind"`
+	MimeType        string    `json:"mimeType"`
+	DurationSeconds *float64  `json:"durationSeconds,omitempty"`
+	URL             string    `json:"url"`
+	Transcript      *string   `json:"transcript,omitempty"`
+}
+
+type UpdateMediaTranscriptRequest struct {
+	Transcript *string `json:"transcript" binding:"omitempty,max=100000"`
+}
+
+// ToPublic converts the attachment into its public representation, preferring
+// the transcoded rendition. It reports false until the attachment has been
+// approved and transcoding has finished.
+func (m *TestimonialMedia) ToPublic() (PublicMedia, bool) {
+	if !m.IsApproved || m.TranscodeStatus != TranscodeReady || m.TranscodedURL == nil {
+		return PublicMedia{}, false
+	}
+	return PublicMedia{
+		ID:              m.ID,
+		Kind:            m.Kind,
+		MimeType:        m.MimeType,
+		DurationSeconds: m.DurationSeconds,
+		URL:             *m.TranscodedURL,
+		Transcript:      m.Transcript,
+	}, true
+}
+
+func (TestimonialMedia) TableName() string {
+	return "testimonial_media"
+}
@@ -0,0 +1,49 @@
+package models
+
+import "github.com/google/uuid"
+
+// BulkAction is a moderation step applied to every testimonial in a bulk
+// request.
+type BulkAction string
+
+const (
+	BulkApprove   BulkAction = "approve"
+	BulkReject    BulkAction = "reject"
+	BulkDelete    BulkAction = "delete"
+	BulkTag       BulkAction = "tag"
+	BulkUntag     BulkAction = "untag"
+	BulkFeature   BulkAction = "feature"
+	BulkUnfeature BulkAction = "unfeature"
+)
+
+// MaxBulkItems caps how many testimonials one bulk request may touch, keeping
+// the transaction and its row locks short.
+const MaxBulkItems = 500
+
+// BulkModerationRequest applies one action to a list of testimonials. Tags is
+// required for the tag and untag actions and ignored otherwise.
+type BulkModerationRequest struct {
+	Action BulkAction  `json:"action" binding:"required,oneof=approve reject delete tag untag feature unfeature"`
+	IDs    []uuid.UUID `json:"ids" binding:"required,min=1,max=500,unique"`
+	Tags   []string    `json:"tags" binding:"required_if=Action tag,required_if=Action untag,max=20,dive,notblank,max=50"`
+}
+
+// BulkItemResult reports the outcome for one testimonial. Code and Message
+// carry the same stable error code and text a single-item request would
+// have returned.
+type BulkItemResult struct {
+	ID      uuid.UUID `json:"id"`
+	Success bool      `json:"success"`
+	Code    string    `json:"code,omitempty"`
+	Message string    `json:"message,omitempty"`
+	Version int64     `json:"version,omitempty"` // New version of a testimonial that was changed
+}
+
+// BulkModerationResult summarizes a bulk request; Results follows the order
+// of the requested IDs.
+type BulkModerationResult struct {
+	Action    BulkAction       `json:"action"`
+	Succeeded int              `json:"succeeded"`
+	Failed    int              `json:"failed"`
+	Results   []BulkItemResult `json:"results"`
+}
@@ -0,0 +1,83 @@
+package models
+
+import "github.com/google/uuid"
+
+// ReactionKind is a way visitors can respond to a testimony.
+type ReactionKind string
+
+const (
+	ReactionAmen   ReactionKind = "amen"
+	ReactionPraise ReactionKind = "praise"
+	ReactionPrayed ReactionKind = "prayed"
+)
+
+// ReactionKinds lists every reaction in display order.
+var ReactionKinds = []ReactionKind{ReactionAmen, ReactionPraise, ReactionPrayed}
+
+// Valid reports whether k is a known reaction.
+func (k ReactionKind) Valid() bool {
+	for _, kind := range ReactionKinds {
+		if k == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// TestimonialReactionCount is the running total of one reaction on one
+// testimonial.
+type TestimonialReactionCount struct {
+	TestimonialID uuid.UUID    `gorm:"column:testimonial_id;type:uuid;primaryKey"`
+	Kind          ReactionKind `gorm:"column:kind;type:varchar(20);primaryKey"`
+	Count         int64        `gorm:"column:count;not null;default:0"`
+}
+
+func (TestimonialReactionCount) TableName() string {
+	return "testimonial_reaction_counts"
+}
+
+// ReactionCounts is the aggregate returned with every testimonial.
+type ReactionCounts struct {
+	Amen   int64 `json:"amen"`
+	Praise int64 `json:"praise"`
+	Prayed int64 `json:"prayed"`
+	Total  int64 `json:"total"`
+}
+
+// NewReactionCounts aggregates stored totals.
+func NewReactionCounts(counts []TestimonialReactionCount) ReactionCounts {
+	var aggregate ReactionCounts
+	for _, c := range counts {
+		switch c.Kind {
+		case ReactionAmen:
+			aggregate.Amen = c.Count
+		case ReactionPraise:
+			aggregate.Praise = c.Count
+		case ReactionPrayed:
+			aggregate.Prayed = c.Count
+		}
+		aggregate.Total += c.Count
+	}
+	return aggregate
+}
+
+// ReactRequest adds a reaction on behalf of the calling visitor.
+type ReactRequest struct {
+	Kind ReactionKind `json:"kind" binding:"required,oneof=amen praise prayed"`
+}
+
+// ReactionResult reports the testimonial's counts after a reaction change.
+// Changed is false when the visitor had already reacted (or had not, when
+// removing), so repeated requests are harmless.
+type ReactionResult struct {
+	TestimonialID uuid.UUID      `json:"testimonialId"`
+	Kind          ReactionKind   `json:"kind"`
+	Changed       bool           `json:"changed"`
+	Reactions     ReactionCounts `json:"reactions"`
+}
+
+// Visitor identifies who is reacting by their IP address and user agent.
+type Visitor struct {
+	ClientIP  string
+	UserAgent string
+}
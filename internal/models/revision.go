@@ -0,0 +1,186 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RevisionAction names the change that produced a revision. Bulk moderation
+// records the BulkAction it applied.
+type RevisionAction string
+
+const (
+	RevisionCreate    RevisionAction = "create"
+	RevisionUpdate    RevisionAction = "update"
+	RevisionApprove   RevisionAction = "approve"
+	RevisionImage     RevisionAction = "image"
+	RevisionFeature   RevisionAction = "feature"
+	RevisionRevert    RevisionAction = "revert"
+	RevisionImport    RevisionAction = "import"
+	RevisionTranslate RevisionAction = "translate"
+)
+
+// TestimonialRevision records one change to a testimonial: who made it,
+// which fields changed, and the resulting state so it can be reverted to.
+type TestimonialRevision struct {
+	ID            uuid.UUID           `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	TestimonialID uuid.UUID           `json:"testimonialId" gorm:"column:testimonial_id;type:uuid;not null;index"`
+	Version       int64               `json:"version" gorm:"column:version;not null"` // Testimonial version this change produced
+	Action        RevisionAction      `json:"action" gorm:"column:action;type:varchar(20);not null"`
+	Actor         string              `json:"actor" gorm:"column:actor;type:varchar(100);not null"`
+	RequestID     string              `json:"requestId,omitempty" gorm:"column:request_id;type:varchar(128)"`
+	Changes       FieldChanges        `json:"changes" gorm:"column:changes;type:jsonb;not null"`
+	Snapshot      TestimonialSnapshot `json:"snapshot" gorm:"column:snapshot;type:jsonb;not null"`
+	CreatedAt     time.Time           `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+}
+
+func (TestimonialRevision) TableName() string {
+	return "testimonial_revisions"
+}
+
+// FieldChange is the before and after value of one field.
+type FieldChange struct {
+	Old any `json:"old"`
+	New any `json:"new"`
+}
+
+// FieldChanges maps snapshot field names to what changed.
+type FieldChanges map[string]FieldChange
+
+func (c FieldChanges) Value() (driver.Value, error) {
+	return jsonValue(c)
+}
+
+func (c *FieldChanges) Scan(src any) error {
+	return scanJSON(src, c)
+}
+
+// TestimonialSnapshot is the revisioned state of a testimonial. The content
+// fields are what a revert restores; the moderation fields and translations
+// are kept for the record only.
+type TestimonialSnapshot struct {
+	FirstName    string  `json:"firstName"`
+	LastName     string  `json:"lastName"`
+	Testimony    string  `json:"testimony"`
+	Language     string  `json:"language,omitempty"` // Empty in snapshots taken before languages were tracked
+	ImageURL     *string `json:"imageUrl"`
+	ThumbnailURL *string `json:"thumbnailUrl"`
+	IsAnonymous  bool    `json:"isAnonymous"`
+
+	IsApproved bool `json:"isApproved"`
+	IsFlagged  bool `json:"isFlagged"`
+	IsFeatured bool `json:"isFeatured"`
+	Tags       Tags `json:"tags"`
+
+	Translations map[string]string `json:"translations,omitempty"`
+}
+
+func (s TestimonialSnapshot) Value() (driver.Value, error) {
+	return jsonValue(s)
+}
+
+func (s *TestimonialSnapshot) Scan(src any) error {
+	return scanJSON(src, s)
+}
+
+// Snapshot captures the testimonial's revisioned state.
+func (t *Testimonial) Snapshot() TestimonialSnapshot {
+	return TestimonialSnapshot{
+		FirstName:    t.FirstName,
+		LastName:     t.LastName,
+		Testimony:    t.Testimony,
+		Language:     t.Language,
+		ImageURL:     t.ImageURL,
+		ThumbnailURL: t.ThumbnailURL,
+		IsAnonymous:  t.IsAnonymous,
+		IsApproved:   t.IsApproved,
+		IsFlagged:    t.IsFlagged,
+		IsFeatured:   t.IsFeatured,
+		Tags:         t.Tags,
+		Translations: TranslationMap(t.Translations),
+	}
+}
+
+// RestoreContent copies the snapshot's content fields back onto t, leaving
+// its moderation state alone. Callers recompute derived fields.
+func (s TestimonialSnapshot) RestoreContent(t *Testimonial) {
+	t.FirstName = s.FirstName
+	t.LastName = s.LastName
+	t.Testimony = s.Testimony
+	t.ImageURL = s.ImageURL
+	t.ThumbnailURL = s.ThumbnailURL
+	t.IsAnonymous = s.IsAnonymous
+	if s.Language != "" {
+		t.Language = s.Language
+	}
+}
+
+// Diff lists the fields that differ between two snapshots, keyed by their
+// JSON names. Omitted fields such as language and translations may appear
+// on only one side, so both are walked.
+func (s TestimonialSnapshot) Diff(next TestimonialSnapshot) FieldChanges {
+	changes := FieldChanges{}
+	before, after := snapshotFields(s), snapshotFields(next)
+	for name, old := range before {
+		if !reflect.DeepEqual(old, after[name]) {
+			changes[name] = FieldChange{Old: old, New: after[name]}
+		}
+	}
+	for name, value := range after {
+		if _, ok := before[name]; !ok {
+			changes[name] = FieldChange{Old: nil, New: value}
+		}
+	}
+	return changes
+}
+
+// snapshotFields flattens a snapshot through JSON so nil and empty values
+// compare the way clients see them.
+func snapshotFields(s TestimonialSnapshot) map[string]any {
+	if s.Tags == nil {
+		s.Tags = Tags{}
+	}
+	data, _ := json.Marshal(s)
+	var fields map[string]any
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
+
+// NewRevision records the change from before to after, which must already
+// carry its new version.
+func NewRevision(before, after *Testimonial, action RevisionAction, actor, requestID string) *TestimonialRevision {
+	snapshot := after.Snapshot()
+	return &TestimonialRevision{
+		TestimonialID: after.ID,
+		Version:       after.Version,
+		Action:        action,
+		Actor:         actor,
+		RequestID:     requestID,
+		Changes:       before.Snapshot().Diff(snapshot),
+		Snapshot:      snapshot,
+	}
+}
+
+func jsonValue(v any) (driver.Value, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func scanJSON(src any, dest any) error {
+	switch v := src.(type) {
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	default:
+		return fmt.Errorf("cannot scan %T into %T", src, dest)
+	}
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// Tags is a set of moderator-assigned labels, stored as a JSONB array so
+// listings can filter on them with a GIN index.
+type Tags []string
+
+// Value stores the tags as a JSON array, never NULL.
+func (t Tags) Value() (driver.Value, error) {
+	if t == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal([]string(t))
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+func (t *Tags) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*t = Tags{}
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into Tags", src)
+	}
+	return json.Unmarshal(data, (*[]string)(t))
+}
+
+// Has reports whether tag is in the set.
+func (t Tags) Has(tag string) bool {
+	for _, existing := range t {
+		if existing == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// Add returns the set with the given tags appended, skipping duplicates.
+func (t Tags) Add(tags ...string) Tags {
+	for _, tag := range tags {
+		if !t.Has(tag) {
+			t = append(t, tag)
+		}
+	}
+	return t
+}
+
+// Remove returns the set without the given tags.
+func (t Tags) Remove(tags ...string) Tags {
+	drop := Tags(tags)
+	kept := make(Tags, 0, len(t))
+	for _, tag := range t {
+		if !drop.Has(tag) {
+			kept = append(kept, tag)
+		}
+	}
+	return kept
+}
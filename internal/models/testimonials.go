@@ -8,36 +8,222 @@ import (
 )
 
 type Testimonial struct {
-	ID          uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	FirstName   string         `json:"firstName" gorm:"column:first_name;type:varchar(100);not null" binding:"required"`
-	LastName    string         `json:"lastName" gorm:"column:last_name;type:varchar(100);not null" binding:"required"`
-	FullName    string         `json:"fullName" gorm:"->;column:full_name;type:varchar(200);generatedAlwaysAs:(first_name || ' ' || last_name) stored"`
-	ImageURL    *string        `json:"imageUrl,omitempty" gorm:"column:image_url;type:varchar(500)"` // Pointer for NULL
-	Testimony   string         `json:"testimony" gorm:"column:testimony;type:text;not null" binding:"required"`
-	IsAnonymous bool           `json:"isAnonymous" gorm:"column:is_anonymous;default:false"`
-	IsApproved  bool           `json:"isApproved" gorm:"column:is_approved;default:false"`
-	CreatedAt   time.Time      `json:"createdAt" gorm:"column:created_at;autoCreateTime"` // Changed from "date" to "createdAt"
-	UpdatedAt   time.Time      `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID            uuid.UUID      `json:"id" gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	FirstName     string         `json:"firstName" gorm:"column:first_name;type:varchar(100);not null" binding:"required"`
+	LastName      string         `json:"lastName" gorm:"column:last_name;type:varchar(100);not null" binding:"required"`
+	FullName      string         `json:"fullName" gorm:"->;column:full_name;type:varchar(200);generatedAlwaysAs:(first_name || ' ' || last_name) stored"`
+	ImageURL      *string        `json:"imageUrl,omitempty" gorm:"column:image_url;type:varchar(500)"` // Pointer for NULL
+	ThumbnailURL  *string        `json:"thumbnailUrl,omitempty" gorm:"column:thumbnail_url;type:varchar(500)"`
+	Testimony     string         `json:"testimony" gorm:"column:testimony;type:text;not null" binding:"required"`
+	Language      string         `json:"language" gorm:"column:language;type:varchar(35);not null;default:'en'"` // BCP 47 tag of the original testimony
+	IsAnonymous   bool           `json:"isAnonymous" gorm:"column:is_anonymous;default:false"`
+	IsApproved    bool           `json:"isApproved" gorm:"column:is_approved;default:false"`
+	IsFlagged     bool           `json:"isFlagged" gorm:"column:is_flagged;default:false"`
+	FlagReasons   *string        `json:"flagReasons,omitempty" gorm:"column:flag_reasons;type:text"`
+	ContentHash   string         `json:"-" gorm:"column:content_hash;type:varchar(64);index"`
+	IsFeatured    bool           `json:"isFeatured" gorm:"column:is_featured;not null;default:false"`
+	FeatureOrder  int            `json:"featureOrder" gorm:"column:feature_order;not null;default:0"` // Lower values come first in the carousel
+	FeaturedFrom  *time.Time     `json:"featuredFrom,omitempty" gorm:"column:featured_from"`          // Optional start of the feature window
+	FeaturedUntil *time.Time     `json:"featuredUntil,omitempty" gorm:"column:featured_until"`        // Optional end of the feature window, exclusive
+	Tags          Tags           `json:"tags" gorm:"column:tags;type:jsonb;not null;default:'[]'"`
+	RejectedAt    *time.Time     `json:"rejectedAt,omitempty" gorm:"column:rejected_at"`    // Set when a moderator rejects, cleared on approval
+	Version       int64          `json:"version" gorm:"column:version;not null;default:1"`  // Bumped on every update for optimistic concurrency
+	CreatedAt     time.Time      `json:"createdAt" gorm:"column:created_at;autoCreateTime"` // Changed from "date" to "createdAt"
+	UpdatedAt     time.Time      `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+
+	Media          []TestimonialMedia         `json:"media,omitempty" gorm:"foreignKey:TestimonialID"`
+	ReactionCounts []TestimonialReactionCount `json:"-" gorm:"foreignKey:TestimonialID"`
+	Translations   []TestimonialTranslation   `json:"-" gorm:"foreignKey:TestimonialID"`
 }
 
 type CreateTestimonialRequest struct {
-	FirstName   string  `json:"firstName" binding:"required"`
-	LastName    string  `json:"lastName" binding:"required"`
-	ImageURL    *string `json:"imageUrl,omitempty"` // Pointer for optional field
-	Testimony   string  `json:"testimony" binding:"required"`
-	IsAnonymous bool    `json:"isAnonymous"`
+	FirstName    string  `json:"firstName" binding:"required,notblank,max=100,personname"`
+	LastName     string  `json:"lastName" binding:"required,notblank,max=100,personname"`
+	ImageURL     *string `json:"imageUrl,omitempty" binding:"omitempty,max=500,httpurl"` // Pointer for optional field
+	Testimony    string  `json:"testimony" binding:"required,notblank,max=10000"`
+	Language     string  `json:"language" binding:"omitempty,max=35,bcp47_language_tag"` // Defaults to English
+	IsAnonymous  bool    `json:"isAnonymous"`
+	Website      string  `json:"website"` // Honeypot: hidden from humans, left empty by real visitors
+	CaptchaToken string  `json:"captchaToken" binding:"max=4096"`
+	ClientIP     string  `json:"-"`
 }
 
 type UpdateTestimonialRequest struct {
-	FirstName   *string `json:"firstName"`
-	LastName    *string `json:"lastName"`
-	ImageURL    *string `json:"imageUrl,omitempty"` // Pointer for optional field
-	Testimony   *string `json:"testimony"`
+	FirstName   *string `json:"firstName" binding:"omitempty,notblank,max=100,personname"`
+	LastName    *string `json:"lastName" binding:"omitempty,notblank,max=100,personname"`
+	ImageURL    *string `json:"imageUrl,omitempty" binding:"omitempty,max=500,httpurl"` // Pointer for optional field
+	Testimony   *string `json:"testimony" binding:"omitempty,notblank,max=10000"`
+	Language    *string `json:"language" binding:"omitempty,max=35,bcp47_language_tag"`
 	IsAnonymous *bool   `json:"isAnonymous"`
 	IsApproved  *bool   `json:"isApproved"`
+	IsFlagged   *bool   `json:"isFlagged"`
+}
+
+// FeatureTestimonialRequest replaces a testimonial's carousel settings. Leaving
+// FeaturedFrom or FeaturedUntil out makes that end of the window open.
+type FeatureTestimonialRequest struct {
+	IsFeatured    bool       `json:"isFeatured"`
+	FeatureOrder  int        `json:"featureOrder" binding:"min=0,max=10000"`
+	FeaturedFrom  *time.Time `json:"featuredFrom"`
+	FeaturedUntil *time.Time `json:"featuredUntil"`
 }
 
 func (Testimonial) TableName() string {
 	return "testimonials"
 }
+
+// AnonymousDisplayName is shown to the public in place of the submitter's name
+// when a testimonial is marked anonymous.
+const AnonymousDisplayName = "Anonymous"
+
+// PublicTestimonial is the representation of a testimonial served to the public.
+// It never carries the submitter's real identity when IsAnonymous is set.
+type PublicTestimonial struct {
+	ID           uuid.UUID      `json:"id"`
+	DisplayName  string         `json:"displayName"`
+	ImageURL     *string        `json:"imageUrl,omitempty"`
+	ThumbnailURL *string        `json:"thumbnailUrl,omitempty"`
+	Testimony    string         `json:"testimony"`
+	Language     string         `json:"language"`  // Language of Testimony as served
+	Languages    []string       `json:"languages"` // Every language the testimony can be read in, the original first
+	IsAnonymous  bool           `json:"isAnonymous"`
+	Media        []PublicMedia  `json:"media,omitempty"`
+	Reactions    ReactionCounts `json:"reactions"`
+	Version      int64          `json:"version"`
+	CreatedAt    time.Time      `json:"createdAt"`
+}
+
+// AdminTestimonial is the representation of a testimonial served to moderators.
+// It always includes the submitter's real identity.
+type AdminTestimonial struct {
+	ID            uuid.UUID                `json:"id"`
+	FirstName     string                   `json:"firstName"`
+	LastName      string                   `json:"lastName"`
+	FullName      string                   `json:"fullName"`
+	DisplayName   string                   `json:"displayName"`
+	ImageURL      *string                  `json:"imageUrl,omitempty"`
+	ThumbnailURL  *string                  `json:"thumbnailUrl,omitempty"`
+	Testimony     string                   `json:"testimony"`
+	Language      string                   `json:"language"`
+	Translations  []TestimonialTranslation `json:"translations"`
+	IsAnonymous   bool                     `json:"isAnonymous"`
+	IsApproved    bool                     `json:"isApproved"`
+	IsFlagged     bool                     `json:"isFlagged"`
+	FlagReasons   *string                  `json:"flagReasons,omitempty"`
+	IsFeatured    bool                     `json:"isFeatured"`
+	FeatureOrder  int                      `json:"featureOrder"`
+	FeaturedFrom  *time.Time               `json:"featuredFrom,omitempty"`
+	FeaturedUntil *time.Time               `json:"featuredUntil,omitempty"`
+	Tags          Tags                     `json:"tags"`
+	RejectedAt    *time.Time               `json:"rejectedAt,omitempty"`
+	Media         []TestimonialMedia       `json:"media,omitempty"`
+	Reactions     ReactionCounts           `json:"reactions"`
+	Version       int64                    `json:"version"`
+	CreatedAt     time.Time                `json:"createdAt"`
+	UpdatedAt     time.Time                `json:"updatedAt"`
+	DeletedAt     *time.Time               `json:"deletedAt,omitempty"` // Set only for testimonials in the trash
+}
+
+// DisplayName returns the name the public should see for this testimonial.
+func (t *Testimonial) DisplayName() string {
+	if t.IsAnonymous {
+		return AnonymousDisplayName
+	}
+	if t.FullName != "" {
+		return t.FullName
+	}
+	return t.FirstName + " " + t.LastName
+}
+
+// ToPublic converts the testimonial into its public representation, in its
+// original language; see PublicTestimonial.Localize. Anonymous testimonials
+// have their name and photo withheld.
+func (t *Testimonial) ToPublic() PublicTestimonial {
+	public := PublicTestimonial{
+		ID:           t.ID,
+		DisplayName:  t.DisplayName(),
+		ImageURL:     t.ImageURL,
+		ThumbnailURL: t.ThumbnailURL,
+		Testimony:    t.Testimony,
+		Language:     t.Language,
+		Languages:    t.Languages(),
+		IsAnonymous:  t.IsAnonymous,
+		Reactions:    NewReactionCounts(t.ReactionCounts),
+		Version:      t.Version,
+		CreatedAt:    t.CreatedAt,
+	}
+	if t.IsAnonymous {
+		// A photo identifies the submitter just as well as a name
+		public.ImageURL = nil
+		public.ThumbnailURL = nil
+	}
+	for i := range t.Media {
+		if media, ok := t.Media[i].ToPublic(); ok {
+			public.Media = append(public.Media, media)
+		}
+	}
+	return public
+}
+
+// ToAdmin converts the testimonial into its moderator representation.
+func (t *Testimonial) ToAdmin() AdminTestimonial {
+	admin := AdminTestimonial{
+		ID:            t.ID,
+		FirstName:     t.FirstName,
+		LastName:      t.LastName,
+		FullName:      t.FullName,
+		DisplayName:   t.DisplayName(),
+		ImageURL:      t.ImageURL,
+		ThumbnailURL:  t.ThumbnailURL,
+		Testimony:     t.Testimony,
+		Language:      t.Language,
+		Translations:  t.Translations,
+		IsAnonymous:   t.IsAnonymous,
+		IsApproved:    t.IsApproved,
+		IsFlagged:     t.IsFlagged,
+		FlagReasons:   t.FlagReasons,
+		IsFeatured:    t.IsFeatured,
+		FeatureOrder:  t.FeatureOrder,
+		FeaturedFrom:  t.FeaturedFrom,
+		FeaturedUntil: t.FeaturedUntil,
+		Tags:          t.Tags,
+		RejectedAt:    t.RejectedAt,
+		Media:         t.Media,
+		Reactions:     NewReactionCounts(t.ReactionCounts),
+		Version:       t.Version,
+		CreatedAt:     t.CreatedAt,
+		UpdatedAt:     t.UpdatedAt,
+	}
+	if admin.Translations == nil {
+		admin.Translations = []TestimonialTranslation{}
+	}
+	if t.DeletedAt.Valid {
+		admin.DeletedAt = &t.DeletedAt.Time
+	}
+	return admin
+}
+
+// TestimonialSort orders testimonial listings.
+type TestimonialSort string
+
+const (
+	// SortNewest lists the most recent testimonials first; it is the default.
+	SortNewest TestimonialSort = "newest"
+	// SortFeatured lists currently featured testimonials first, in their
+	// manual order, followed by the rest newest first.
+	SortFeatured TestimonialSort = "featured"
+	// SortEncouraging lists the testimonials with the most reactions first.
+	SortEncouraging TestimonialSort = "encouraging"
+)
+
+// TestimonialFilter narrows testimonial listings.
+type TestimonialFilter struct {
+	ApprovedOnly bool
+	FlaggedOnly  bool
+	Tag          string // Only testimonials carrying this tag
+	Sort         TestimonialSort
+	Search       string     // Matched against the testimony and media transcripts
+	From         *time.Time // Created at or after
+	To           *time.Time // Created before
+}
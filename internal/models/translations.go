@@ -0,0 +1,83 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+	"wisdomHouse-backend/internal/i18n"
+)
+
+// TestimonialTranslation is a testimony rendered in a language other than the
+// one it was written in.
+type TestimonialTranslation struct {
+	TestimonialID uuid.UUID `json:"-" gorm:"column:testimonial_id;type:uuid;primaryKey"`
+	Language      string    `json:"language" gorm:"column:language;type:varchar(35);primaryKey"`
+	Testimony     string    `json:"testimony" gorm:"column:testimony;type:text;not null"`
+	CreatedAt     time.Time `json:"createdAt" gorm:"column:created_at;autoCreateTime"`
+	UpdatedAt     time.Time `json:"updatedAt" gorm:"column:updated_at;autoUpdateTime"`
+}
+
+func (TestimonialTranslation) TableName() string {
+	return "testimonial_translations"
+}
+
+// TranslationRequest adds or replaces the translation in the language named
+// by the request path.
+type TranslationRequest struct {
+	Testimony string `json:"testimony" binding:"required,notblank,max=10000"`
+}
+
+// Languages lists the languages the testimony can be read in, the original
+// first.
+func (t *Testimonial) Languages() []string {
+	languages := make([]string, 0, len(t.Translations)+1)
+	languages = append(languages, t.Language)
+	for _, translation := range t.Translations {
+		languages = append(languages, translation.Language)
+	}
+	return languages
+}
+
+// Localize replaces the testimony with the translation that best serves
+// prefs, if one serves them better than the original. p must still carry the
+// original testimony, as produced by ToPublic.
+func (p *PublicTestimonial) Localize(translations []TestimonialTranslation, prefs []language.Tag) {
+	if len(translations) == 0 {
+		return
+	}
+	available := make([]string, 0, len(translations)+1)
+	available = append(available, p.Language)
+	for _, translation := range translations {
+		available = append(available, translation.Language)
+	}
+	index, ok := i18n.Match(prefs, available)
+	if !ok || index == 0 {
+		return
+	}
+	p.Language = translations[index-1].Language
+	p.Testimony = translations[index-1].Testimony
+}
+
+// TranslationMap keys translated testimonies by language, for revision
+// snapshots.
+func TranslationMap(translations []TestimonialTranslation) map[string]string {
+	if len(translations) == 0 {
+		return nil
+	}
+	texts := make(map[string]string, len(translations))
+	for _, translation := range translations {
+		texts[translation.Language] = translation.Testimony
+	}
+	return texts
+}
+
+// Translation returns the testimonial's translation into language, or nil.
+func (t *Testimonial) Translation(language string) *TestimonialTranslation {
+	for i := range t.Translations {
+		if t.Translations[i].Language == language {
+			return &t.Translations[i]
+		}
+	}
+	return nil
+}
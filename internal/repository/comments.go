@@ -0,0 +1,138 @@
+package repository
+
+import (
+    "context"
+
+    "github.com/google/uuid"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+    "wisdomHouse-backend/internal/database"
+    "wisdomHouse-backend/internal/models"
+)
+
+type CommentRepository interface {
+    Create(ctx context.Context, comment *models.Comment) error
+    GetByID(ctx context.Context, testimonialID, id uuid.UUID) (*models.Comment, error)
+    GetByIDForUpdate(ctx context.Context, testimonialID, id uuid.UUID) (*models.Comment, error)
+    Update(ctx context.Context, comment *models.Comment) error
+    Delete(ctx context.Context, id uuid.UUID) error
+    GetApprovedThreadsPaginated(ctx context.Context, testimonialID uuid.UUID, page, limit int) ([]models.Comment, int64, error)
+    GetPaginated(ctx context.Context, page, limit int, filter models.CommentFilter) ([]models.Comment, int64, error)
+}
+
+type commentRepository struct {
+    db *database.Database
+}
+
+func NewCommentRepository(db *database.Database) CommentRepository {
+    return &commentRepository{db: db}
+}
+
+func (r *commentRepository) Create(ctx context.Context, comment *models.Comment) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Create(comment).Error
+}
+
+// GetByID returns gorm.ErrRecordNotFound unless the comment belongs to the
+// testimonial.
+func (r *commentRepository) GetByID(ctx context.Context, testimonialID, id uuid.UUID) (*models.Comment, error) {
+    var comment models.Comment
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Where("id = ? AND testimonial_id = ?", id, testimonialID).First(&comment).Error
+    if err != nil {
+        return nil, err
+    }
+    return &comment, nil
+}
+
+// GetByIDForUpdate is GetByID that also locks the comment's row until the
+// surrounding transaction ends, so concurrent moderation is serialized.
+func (r *commentRepository) GetByIDForUpdate(ctx context.Context, testimonialID, id uuid.UUID) (*models.Comment, error) {
+    var comment models.Comment
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Clauses(clause.Locking{Strength: "UPDATE"}).
+        Where("id = ? AND testimonial_id = ?", id, testimonialID).First(&comment).Error
+    if err != nil {
+        return nil, err
+    }
+    return &comment, nil
+}
+
+func (r *commentRepository) Update(ctx context.Context, comment *models.Comment) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Omit("Replies").Save(comment).Error
+}
+
+func (r *commentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Delete(&models.Comment{}, "id = ?", id).Error
+}
+
+// GetApprovedThreadsPaginated pages through a testimonial's approved
+// top-level comments, oldest first so conversations read in order, each with
+// its approved replies. Served from a read replica when one is healthy.
+func (r *commentRepository) GetApprovedThreadsPaginated(ctx context.Context, testimonialID uuid.UUID, page, limit int) ([]models.Comment, int64, error) {
+    var comments []models.Comment
+    var total int64
+    
+    db, cancel := r.db.Reader(ctx)
+    defer cancel()
+    query := db.Model(&models.Comment{}).
+        Where("testimonial_id = ? AND parent_id IS NULL AND is_approved = ?", testimonialID, true)
+    
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, err
+    }
+    
+    offset := (page - 1) * limit
+    err := query.
+        Preload("Replies", func(db *gorm.DB) *gorm.DB {
+            return db.Where("is_approved = ?", true).Order("created_at ASC")
+        }).
+        Order("created_at ASC").Limit(limit).Offset(offset).Find(&comments).Error
+    
+    return comments, total, err
+}
+
+// GetPaginated lists comments for moderators, newest first, without nesting.
+func (r *commentRepository) GetPaginated(ctx context.Context, page, limit int, filter models.CommentFilter) ([]models.Comment, int64, error) {
+    var comments []models.Comment
+    var total int64
+    
+    db, cancel := r.db.Reader(ctx)
+    defer cancel()
+    query := applyCommentFilter(db.Model(&models.Comment{}), filter)
+    
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, err
+    }
+    
+    offset := (page - 1) * limit
+    err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&comments).Error
+    
+    return comments, total, err
+}
+
+func applyCommentFilter(query *gorm.DB, filter models.CommentFilter) *gorm.DB {
+    if filter.TestimonialID != nil {
+        query = query.Where("testimonial_id = ?", *filter.TestimonialID)
+    }
+    
+    switch filter.Status {
+    case models.CommentStatusPending:
+        query = query.Where("is_approved = ? AND rejected_at IS NULL", false)
+    case models.CommentStatusFlagged:
+        query = query.Where("is_flagged = ?", true)
+    case models.CommentStatusApproved:
+        query = query.Where("is_approved = ?", true)
+    case models.CommentStatusRejected:
+        query = query.Where("rejected_at IS NOT NULL")
+    }
+    
+    return query
+}
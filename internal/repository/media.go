@@ -0,0 +1,78 @@
+package repository
+
+import (
+    "context"
+
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/database"
+    "wisdomHouse-backend/internal/models"
+)
+
+type MediaRepository interface {
+    Create(ctx context.Context, media *models.TestimonialMedia) error
+    GetByID(ctx context.Context, id uuid.UUID) (*models.TestimonialMedia, error)
+    GetByTestimonial(ctx context.Context, testimonialID uuid.UUID) ([]models.TestimonialMedia, error)
+    Update(ctx context.Context, media *models.TestimonialMedia) error
+    Approve(ctx context.Context, id uuid.UUID) error
+    Delete(ctx context.Context, id uuid.UUID) error
+}
+
+type mediaRepository struct {
+    db *database.Database
+}
+
+func NewMediaRepository(db *database.Database) MediaRepository {
+    return &mediaRepository{db: db}
+}
+
+func (r *mediaRepository) Create(ctx context.Context, media *models.TestimonialMedia) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Create(media).Error
+}
+
+func (r *mediaRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.TestimonialMedia, error) {
+    var media models.TestimonialMedia
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Where("id = ?", id).First(&media).Error
+    if err != nil {
+        return nil, err
+    }
+    return &media, nil
+}
+
+func (r *mediaRepository) GetByTestimonial(ctx context.Context, testimonialID uuid.UUID) ([]models.TestimonialMedia, error) {
+    var media []models.TestimonialMedia
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Where("testimonial_id = ?", testimonialID).Order("created_at ASC").Find(&media).Error
+    return media, err
+}
+
+// Update saves the record but never its moderation state, so a transcode
+// finishing with a stale copy cannot undo a moderator's approval. Use
+// Approve for that.
+func (r *mediaRepository) Update(ctx context.Context, media *models.TestimonialMedia) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Omit("IsApproved", "IsFlagged", "FlagReasons").Save(media).Error
+}
+
+// Approve publishes the recording; approval is the moderator's review of any
+// flags, so they are cleared.
+func (r *mediaRepository) Approve(ctx context.Context, id uuid.UUID) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Model(&models.TestimonialMedia{}).Where("id = ?", id).Updates(map[string]any{
+        "is_approved":  true,
+        "is_flagged":   false,
+        "flag_reasons": nil,
+    }).Error
+}
+
+func (r *mediaRepository) Delete(ctx context.Context, id uuid.UUID) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Delete(&models.TestimonialMedia{}, "id = ?", id).Error
+}
\ No newline at end of file
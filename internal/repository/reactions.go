@@ -0,0 +1,55 @@
+package repository
+
+import (
+    "context"
+
+    "github.com/google/uuid"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
+    "wisdomHouse-backend/internal/database"
+    "wisdomHouse-backend/internal/models"
+)
+
+// ReactionRepository keeps reaction totals. It does not know who reacted;
+// deduplication is the caller's job.
+type ReactionRepository interface {
+    Increment(ctx context.Context, testimonialID uuid.UUID, kind models.ReactionKind) error
+    Decrement(ctx context.Context, testimonialID uuid.UUID, kind models.ReactionKind) error
+    GetCounts(ctx context.Context, testimonialID uuid.UUID) ([]models.TestimonialReactionCount, error)
+}
+
+type reactionRepository struct {
+    db *database.Database
+}
+
+func NewReactionRepository(db *database.Database) ReactionRepository {
+    return &reactionRepository{db: db}
+}
+
+// Increment adds one to the total, creating it on the first reaction. The
+// upsert is atomic, so concurrent reactions are never lost.
+func (r *reactionRepository) Increment(ctx context.Context, testimonialID uuid.UUID, kind models.ReactionKind) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "testimonial_id"}, {Name: "kind"}},
+        DoUpdates: clause.Assignments(map[string]any{"count": gorm.Expr("testimonial_reaction_counts.count + 1")}),
+    }).Create(&models.TestimonialReactionCount{TestimonialID: testimonialID, Kind: kind, Count: 1}).Error
+}
+
+// Decrement removes one from the total, never going below zero.
+func (r *reactionRepository) Decrement(ctx context.Context, testimonialID uuid.UUID, kind models.ReactionKind) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Model(&models.TestimonialReactionCount{}).
+        Where("testimonial_id = ? AND kind = ? AND count > 0", testimonialID, kind).
+        UpdateColumn("count", gorm.Expr("count - 1")).Error
+}
+
+func (r *reactionRepository) GetCounts(ctx context.Context, testimonialID uuid.UUID) ([]models.TestimonialReactionCount, error) {
+    var counts []models.TestimonialReactionCount
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Where("testimonial_id = ?", testimonialID).Find(&counts).Error
+    return counts, err
+}
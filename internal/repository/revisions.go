@@ -0,0 +1,70 @@
+package repository
+
+import (
+    "context"
+
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/database"
+    "wisdomHouse-backend/internal/models"
+)
+
+// RevisionRepository stores the edit history of testimonials. Revisions are
+// append-only and removed only when their testimonial is purged.
+type RevisionRepository interface {
+    Create(ctx context.Context, revision *models.TestimonialRevision) error
+    CreateBatch(ctx context.Context, revisions []models.TestimonialRevision) error
+    GetByID(ctx context.Context, testimonialID, id uuid.UUID) (*models.TestimonialRevision, error)
+    GetByTestimonialPaginated(ctx context.Context, testimonialID uuid.UUID, page, limit int) ([]models.TestimonialRevision, int64, error)
+}
+
+type revisionRepository struct {
+    db *database.Database
+}
+
+func NewRevisionRepository(db *database.Database) RevisionRepository {
+    return &revisionRepository{db: db}
+}
+
+func (r *revisionRepository) Create(ctx context.Context, revision *models.TestimonialRevision) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Create(revision).Error
+}
+
+func (r *revisionRepository) CreateBatch(ctx context.Context, revisions []models.TestimonialRevision) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Create(&revisions).Error
+}
+
+// GetByID returns gorm.ErrRecordNotFound if the revision does not belong to
+// the testimonial.
+func (r *revisionRepository) GetByID(ctx context.Context, testimonialID, id uuid.UUID) (*models.TestimonialRevision, error) {
+    var revision models.TestimonialRevision
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Where("id = ? AND testimonial_id = ?", id, testimonialID).First(&revision).Error
+    if err != nil {
+        return nil, err
+    }
+    return &revision, nil
+}
+
+// GetByTestimonialPaginated lists a testimonial's revisions, newest first.
+func (r *revisionRepository) GetByTestimonialPaginated(ctx context.Context, testimonialID uuid.UUID, page, limit int) ([]models.TestimonialRevision, int64, error) {
+    var revisions []models.TestimonialRevision
+    var total int64
+    
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    query := db.Model(&models.TestimonialRevision{}).Where("testimonial_id = ?", testimonialID)
+    
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, err
+    }
+    
+    offset := (page - 1) * limit
+    err := query.Order("version DESC, created_at DESC").Limit(limit).Offset(offset).Find(&revisions).Error
+    
+    return revisions, total, err
+}
@@ -1,18 +1,56 @@
 package repository
 
 import (
+    "context"
+    "database/sql"
+    "errors"
+    "strings"
+    "time"
+
     "github.com/google/uuid"
+    "gorm.io/gorm"
+    "gorm.io/gorm/clause"
     "wisdomHouse-backend/internal/database"
     "wisdomHouse-backend/internal/models"
 )
 
+// ErrVersionConflict is returned by Update when the testimonial changed since
+// it was loaded.
+var ErrVersionConflict = errors.New("testimonial version conflict")
+
 type TestimonialRepository interface {
-    Create(testimonial *models.Testimonial) error
-    GetAll(approved bool) ([]models.Testimonial, error)
-    GetByID(id uuid.UUID) (*models.Testimonial, error)
-    Update(testimonial *models.Testimonial) error
-    Delete(id uuid.UUID) error
-    GetPaginated(page, limit int, approved bool) ([]models.Testimonial, int64, error)
+    Create(ctx context.Context, testimonial *models.Testimonial) error
+    GetAll(ctx context.Context, filter models.TestimonialFilter) ([]models.Testimonial, error)
+    GetByID(ctx context.Context, id uuid.UUID) (*models.Testimonial, error)
+    GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Testimonial, error)
+    Update(ctx context.Context, testimonial *models.Testimonial) error
+    Delete(ctx context.Context, id uuid.UUID) error
+    GetPaginated(ctx context.Context, page, limit int, filter models.TestimonialFilter) ([]models.Testimonial, int64, error)
+    ExistsByContentHash(ctx context.Context, hash string) (bool, error)
+    
+    // Export: walks every matching testimonial in batches, oldest first
+    ExportBatches(ctx context.Context, filter models.TestimonialFilter, batchSize int, fn func([]models.Testimonial) error) error
+    
+    // Import: bulk inserts with deduplication by content hash
+    CreateBatch(ctx context.Context, testimonials []models.Testimonial) error
+    ExistingContentHashes(ctx context.Context, hashes []string) (map[string]bool, error)
+    
+    // Translations: the testimony in languages other than its original
+    SaveTranslation(ctx context.Context, translation *models.TestimonialTranslation) error
+    DeleteTranslation(ctx context.Context, id uuid.UUID, language string) error
+    
+    // Feeds: syndication of approved testimonials
+    LastModified(ctx context.Context) (*time.Time, error)
+    
+    // Featured: the home page carousel
+    GetFeatured(ctx context.Context, now time.Time, limit int) ([]models.Testimonial, error)
+    NextFeatureChange(ctx context.Context, now time.Time) (*time.Time, error)
+
+    // Trash: soft-deleted testimonials
+    GetDeletedPaginated(ctx context.Context, page, limit int) ([]models.Testimonial, int64, error)
+    Restore(ctx context.Context, id uuid.UUID) error
+    Purge(ctx context.Context, id uuid.UUID) error
+    PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error)
 }
 
 type testimonialRepository struct {
@@ -23,48 +61,98 @@ func NewTestimonialRepository(db *database.Database) TestimonialRepository {
     return &testimonialRepository{db: db}
 }
 
-func (r *testimonialRepository) Create(testimonial *models.Testimonial) error {
-    return r.db.DB.Create(testimonial).Error
+func (r *testimonialRepository) Create(ctx context.Context, testimonial *models.Testimonial) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Create(testimonial).Error
 }
 
-func (r *testimonialRepository) GetAll(approved bool) ([]models.Testimonial, error) {
+// GetAll and GetPaginated serve listings from a read replica when one is
+// healthy, so they may briefly miss the latest writes.
+func (r *testimonialRepository) GetAll(ctx context.Context, filter models.TestimonialFilter) ([]models.Testimonial, error) {
     var testimonials []models.Testimonial
-    query := r.db.DB.Order("created_at DESC")
-    
-    if approved {
-        query = query.Where("is_approved = ?", true)
-    }
+    db, cancel := r.db.Reader(ctx)
+    defer cancel()
+    query := applyFilter(applySort(db, filter.Sort), filter)
     
-    err := query.Find(&testimonials).Error
+    err := query.Preload("Media").Preload("ReactionCounts").Preload("Translations").Find(&testimonials).Error
     return testimonials, err
 }
 
-func (r *testimonialRepository) GetByID(id uuid.UUID) (*models.Testimonial, error) {
+func (r *testimonialRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Testimonial, error) {
+    var testimonial models.Testimonial
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Preload("Media").Preload("ReactionCounts").Preload("Translations").Where("id = ?", id).First(&testimonial).Error
+    if err != nil {
+        return nil, err
+    }
+    return &testimonial, nil
+}
+
+// GetByIDForUpdate loads a testimonial and locks its row until the enclosing
+// transaction ends, so concurrent read-modify-write cycles run one at a time.
+// It must be called within UnitOfWork.Do.
+func (r *testimonialRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*models.Testimonial, error) {
     var testimonial models.Testimonial
-    err := r.db.DB.Where("id = ?", id).First(&testimonial).Error
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).First(&testimonial).Error
+    if err != nil {
+        return nil, err
+    }
+    
+    err = db.Where("testimonial_id = ?", id).Order("created_at ASC").Find(&testimonial.Media).Error
+    if err != nil {
+        return nil, err
+    }
+    
+    err = db.Where("testimonial_id = ?", id).Find(&testimonial.ReactionCounts).Error
+    if err != nil {
+        return nil, err
+    }
+    
+    err = db.Where("testimonial_id = ?", id).Order("language ASC").Find(&testimonial.Translations).Error
     if err != nil {
         return nil, err
     }
     return &testimonial, nil
 }
 
-func (r *testimonialRepository) Update(testimonial *models.Testimonial) error {
-    return r.db.DB.Save(testimonial).Error
+// Update saves the testimonial's own columns and bumps its version, but only
+// if the stored version still equals testimonial.Version; otherwise it returns
+// ErrVersionConflict and leaves the row alone. Media attachments are updated
+// through MediaRepository so a stale preloaded copy never overwrites them.
+func (r *testimonialRepository) Update(ctx context.Context, testimonial *models.Testimonial) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    
+    expected := testimonial.Version
+    testimonial.Version = expected + 1
+    result := db.Model(testimonial).Omit(clause.Associations).Select("*").Where("version = ?", expected).Updates(testimonial)
+    if result.Error == nil && result.RowsAffected == 0 {
+        result.Error = ErrVersionConflict
+    }
+    if result.Error != nil {
+        testimonial.Version = expected
+        return result.Error
+    }
+    return nil
 }
 
-func (r *testimonialRepository) Delete(id uuid.UUID) error {
-    return r.db.DB.Delete(&models.Testimonial{}, "id = ?", id).Error
+func (r *testimonialRepository) Delete(ctx context.Context, id uuid.UUID) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Delete(&models.Testimonial{}, "id = ?", id).Error
 }
 
-func (r *testimonialRepository) GetPaginated(page, limit int, approved bool) ([]models.Testimonial, int64, error) {
+func (r *testimonialRepository) GetPaginated(ctx context.Context, page, limit int, filter models.TestimonialFilter) ([]models.Testimonial, int64, error) {
     var testimonials []models.Testimonial
     var total int64
     
-    query := r.db.DB.Model(&models.Testimonial{})
-    
-    if approved {
-        query = query.Where("is_approved = ?", true)
-    }
+    db, cancel := r.db.Reader(ctx)
+    defer cancel()
+    query := applyFilter(db.Model(&models.Testimonial{}), filter)
     
     // Count total records
     if err := query.Count(&total).Error; err != nil {
@@ -73,7 +161,269 @@ func (r *testimonialRepository) GetPaginated(page, limit int, approved bool) ([]
     
     // Get paginated records
     offset := (page - 1) * limit
-    err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&testimonials).Error
+    err := applySort(query, filter.Sort).Preload("Media").Preload("ReactionCounts").Preload("Translations").Limit(limit).Offset(offset).Find(&testimonials).Error
     
     return testimonials, total, err
+}
+
+// activeFeature matches approved testimonials that are featured and inside
+// their feature window at the time given as its two arguments.
+const activeFeature = "is_approved AND is_featured AND (featured_from IS NULL OR featured_from <= ?) AND (featured_until IS NULL OR featured_until > ?)"
+
+// GetFeatured lists the testimonials in the carousel at now, in their manual
+// order. It reads from the primary: the result is cached, and a replica that
+// lags behind a change would keep the stale list cached until it expires.
+func (r *testimonialRepository) GetFeatured(ctx context.Context, now time.Time, limit int) ([]models.Testimonial, error) {
+    var testimonials []models.Testimonial
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Where(activeFeature, now, now).
+        Order("feature_order ASC, created_at DESC").
+        Limit(limit).
+        Preload("Media").Preload("ReactionCounts").Preload("Translations").
+        Find(&testimonials).Error
+    return testimonials, err
+}
+
+// NextFeatureChange returns the next time after now at which a scheduled
+// feature starts or ends, or nil if none is pending.
+func (r *testimonialRepository) NextFeatureChange(ctx context.Context, now time.Time) (*time.Time, error) {
+    var next sql.NullTime
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Model(&models.Testimonial{}).
+        Select("LEAST(MIN(featured_from) FILTER (WHERE featured_from > ?), MIN(featured_until) FILTER (WHERE featured_until > ?))", now, now).
+        Where("is_approved AND is_featured").
+        Scan(&next).Error
+    if err != nil || !next.Valid {
+        return nil, err
+    }
+    return &next.Time, nil
+}
+
+// LastModified returns when any testimonial last changed, including soft
+// deletes, or nil if there are none. Approvals, edits and removals all move
+// it forward, so it is a safe Last-Modified for anything built from
+// testimonials. Like the listings it may read from a replica.
+func (r *testimonialRepository) LastModified(ctx context.Context) (*time.Time, error) {
+    var last sql.NullTime
+    db, cancel := r.db.Reader(ctx)
+    defer cancel()
+    err := db.Unscoped().Model(&models.Testimonial{}).
+        Select("GREATEST(MAX(updated_at), MAX(deleted_at))").
+        Scan(&last).Error
+    if err != nil || !last.Valid {
+        return nil, err
+    }
+    return &last.Time, nil
+}
+
+// ExportBatches calls fn with successive batches of testimonials matching
+// filter, oldest first; filter.Sort is ignored. Batches are fetched by
+// keyset on (created_at, id), so each query is cheap however large the
+// export and rows written meanwhile are never returned twice.
+func (r *testimonialRepository) ExportBatches(ctx context.Context, filter models.TestimonialFilter, batchSize int, fn func([]models.Testimonial) error) error {
+    var lastCreatedAt time.Time
+    var lastID uuid.UUID
+    
+    for first := true; ; first = false {
+        var batch []models.Testimonial
+        err := func() error {
+            db, cancel := r.db.Reader(ctx)
+            defer cancel()
+            query := applyFilter(db.Model(&models.Testimonial{}), filter)
+            if !first {
+                query = query.Where("(created_at, id) > (?, ?)", lastCreatedAt, lastID)
+            }
+            return query.Preload("ReactionCounts").Preload("Translations").
+                Order("created_at ASC, id ASC").Limit(batchSize).Find(&batch).Error
+        }()
+        if err != nil {
+            return err
+        }
+        if len(batch) == 0 {
+            return nil
+        }
+        
+        if err := fn(batch); err != nil {
+            return err
+        }
+        if len(batch) < batchSize {
+            return nil
+        }
+        last := batch[len(batch)-1]
+        lastCreatedAt, lastID = last.CreatedAt, last.ID
+    }
+}
+
+// CreateBatch inserts testimonials in a single statement, so callers should
+// keep batches to a few hundred rows.
+func (r *testimonialRepository) CreateBatch(ctx context.Context, testimonials []models.Testimonial) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Omit("Media", "ReactionCounts", "Translations").Create(&testimonials).Error
+}
+
+// ExistingContentHashes reports which of hashes already belong to a
+// testimonial, including ones in the trash, which could still be restored.
+func (r *testimonialRepository) ExistingContentHashes(ctx context.Context, hashes []string) (map[string]bool, error) {
+    existing := make(map[string]bool)
+    if len(hashes) == 0 {
+        return existing, nil
+    }
+    
+    var found []string
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Unscoped().Model(&models.Testimonial{}).
+        Where("content_hash IN ?", hashes).Distinct().Pluck("content_hash", &found).Error
+    if err != nil {
+        return nil, err
+    }
+    
+    for _, hash := range found {
+        existing[hash] = true
+    }
+    return existing, nil
+}
+
+// SaveTranslation adds the translation or replaces the one already stored in
+// its language.
+func (r *testimonialRepository) SaveTranslation(ctx context.Context, translation *models.TestimonialTranslation) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    return db.Clauses(clause.OnConflict{
+        Columns:   []clause.Column{{Name: "testimonial_id"}, {Name: "language"}},
+        DoUpdates: clause.AssignmentColumns([]string{"testimony", "updated_at"}),
+    }).Create(translation).Error
+}
+
+// DeleteTranslation removes a testimonial's translation into language. It
+// returns gorm.ErrRecordNotFound if there is none.
+func (r *testimonialRepository) DeleteTranslation(ctx context.Context, id uuid.UUID, language string) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    result := db.Where("testimonial_id = ? AND language = ?", id, language).Delete(&models.TestimonialTranslation{})
+    if result.Error == nil && result.RowsAffected == 0 {
+        return gorm.ErrRecordNotFound
+    }
+    return result.Error
+}
+
+func (r *testimonialRepository) ExistsByContentHash(ctx context.Context, hash string) (bool, error) {
+    var count int64
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    err := db.Model(&models.Testimonial{}).Where("content_hash = ?", hash).Limit(1).Count(&count).Error
+    return count > 0, err
+}
+
+// GetDeletedPaginated lists soft-deleted testimonials, most recently deleted
+// first.
+func (r *testimonialRepository) GetDeletedPaginated(ctx context.Context, page, limit int) ([]models.Testimonial, int64, error) {
+    var testimonials []models.Testimonial
+    var total int64
+    
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    query := db.Unscoped().Model(&models.Testimonial{}).Where("deleted_at IS NOT NULL")
+    
+    if err := query.Count(&total).Error; err != nil {
+        return nil, 0, err
+    }
+    
+    offset := (page - 1) * limit
+    err := query.Preload("Media").Preload("ReactionCounts").Preload("Translations").Order("deleted_at DESC").Limit(limit).Offset(offset).Find(&testimonials).Error
+    
+    return testimonials, total, err
+}
+
+// Restore undoes a soft delete and bumps the version so stale ETags from
+// before the deletion no longer match. It returns gorm.ErrRecordNotFound if
+// the testimonial is not in the trash.
+func (r *testimonialRepository) Restore(ctx context.Context, id uuid.UUID) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    result := db.Unscoped().Model(&models.Testimonial{}).
+        Where("id = ? AND deleted_at IS NOT NULL", id).
+        Updates(map[string]any{"deleted_at": nil, "version": gorm.Expr("version + 1")})
+    if result.Error == nil && result.RowsAffected == 0 {
+        return gorm.ErrRecordNotFound
+    }
+    return result.Error
+}
+
+// Purge permanently deletes a testimonial that is already in the trash,
+// along with its media records. It returns gorm.ErrRecordNotFound otherwise,
+// so a live testimonial can never be purged by mistake.
+func (r *testimonialRepository) Purge(ctx context.Context, id uuid.UUID) error {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    result := db.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(&models.Testimonial{})
+    if result.Error == nil && result.RowsAffected == 0 {
+        return gorm.ErrRecordNotFound
+    }
+    return result.Error
+}
+
+// PurgeDeletedBefore permanently deletes testimonials soft-deleted before
+// cutoff and reports how many were removed.
+func (r *testimonialRepository) PurgeDeletedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+    db, cancel := r.db.WithTimeout(ctx)
+    defer cancel()
+    result := db.Unscoped().Where("deleted_at IS NOT NULL AND deleted_at < ?", cutoff).Delete(&models.Testimonial{})
+    return result.RowsAffected, result.Error
+}
+
+// applySort orders a listing. The featured order is a single expression
+// because GORM drops earlier ORDER BY columns when an expression is merged.
+func applySort(query *gorm.DB, sort models.TestimonialSort) *gorm.DB {
+    switch sort {
+    case models.SortFeatured:
+        now := time.Now()
+        return query.Order(clause.OrderBy{Expression: clause.Expr{
+            SQL:  "CASE WHEN " + activeFeature + " THEN 0 ELSE 1 END, feature_order ASC, created_at DESC",
+            Vars: []any{now, now},
+        }})
+    case models.SortEncouraging:
+        return query.Order("(SELECT COALESCE(SUM(r.count), 0) FROM testimonial_reaction_counts r WHERE r.testimonial_id = testimonials.id) DESC, created_at DESC")
+    default:
+        return query.Order("created_at DESC")
+    }
+}
+
+// likeEscaper makes search terms match literally in a LIKE pattern escaped
+// with a backslash, so "%" and "_" typed by a visitor are not wildcards.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+func applyFilter(query *gorm.DB, filter models.TestimonialFilter) *gorm.DB {
+    if filter.ApprovedOnly {
+        query = query.Where("is_approved = ?", true)
+    }
+    
+    if filter.FlaggedOnly {
+        query = query.Where("is_flagged = ?", true)
+    }
+    
+    if filter.Tag != "" {
+        query = query.Where("tags @> ?", models.Tags{filter.Tag})
+    }
+    
+    if filter.From != nil {
+        query = query.Where("created_at >= ?", *filter.From)
+    }
+    
+    if filter.To != nil {
+        query = query.Where("created_at < ?", *filter.To)
+    }
+    
+    if filter.Search != "" {
+        pattern := "%" + likeEscaper.Replace(filter.Search) + "%"
+        query = query.Where(
+            `testimony ILIKE ? ESCAPE '\' OR EXISTS (SELECT 1 FROM testimonial_media m WHERE m.testimonial_id = testimonials.id AND m.deleted_at IS NULL AND m.transcript ILIKE ? ESCAPE '\')`,
+            pattern, pattern,
+        )
+    }
+    
+    return query
 }
\ No newline at end of file
@@ -0,0 +1,26 @@
+package repository
+
+import (
+    "context"
+
+    "wisdomHouse-backend/internal/database"
+)
+
+// UnitOfWork runs several repository operations atomically. Repositories
+// called with the ctx handed to fn are bound to its transaction; returning an
+// error from fn rolls everything back.
+type UnitOfWork interface {
+    Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type unitOfWork struct {
+    db *database.Database
+}
+
+func NewUnitOfWork(db *database.Database) UnitOfWork {
+    return &unitOfWork{db: db}
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+    return u.db.Transaction(ctx, fn)
+}
\ No newline at end of file
@@ -0,0 +1,93 @@
+package screening
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// CaptchaVerifier checks a CAPTCHA response token with its provider.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// SiteVerifyCaptcha verifies tokens against a "siteverify" endpoint, the API
+// shared by reCAPTCHA, hCaptcha and Cloudflare Turnstile.
+type SiteVerifyCaptcha struct {
+	verifyURL string
+	secret    string
+	client    *http.Client
+}
+
+func NewSiteVerifyCaptcha(verifyURL, secret string) *SiteVerifyCaptcha {
+	return &SiteVerifyCaptcha{
+		verifyURL: verifyURL,
+		secret:    secret,
+		client:    &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (v *SiteVerifyCaptcha) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	form := url.Values{
+		"secret":   {v.secret},
+		"response": {token},
+	}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, v.verifyURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("captcha verification request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("captcha provider returned %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Success bool `json:"success"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode captcha response: %w", err)
+	}
+	return result.Success, nil
+}
+
+// CaptchaRule rejects submissions without a valid CAPTCHA token.
+type CaptchaRule struct {
+	verifier CaptchaVerifier
+}
+
+func NewCaptchaRule(verifier CaptchaVerifier) *CaptchaRule {
+	return &CaptchaRule{verifier: verifier}
+}
+
+func (r *CaptchaRule) Name() string { return "captcha" }
+
+func (r *CaptchaRule) Check(ctx context.Context, sub *Submission) (Verdict, string, error) {
+	if sub.CaptchaToken == "" {
+		return Reject, "captcha token missing", nil
+	}
+	ok, err := r.verifier.Verify(ctx, sub.CaptchaToken, sub.ClientIP)
+	if err != nil {
+		// Don't turn every visitor away during a provider outage; let a
+		// moderator take a look instead.
+		return Flag, fmt.Sprintf("captcha could not be verified: %v", err), nil
+	}
+	if !ok {
+		return Reject, "captcha verification failed", nil
+	}
+	return Allow, "", nil
+}
@@ -0,0 +1,138 @@
+package screening
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// LengthRule rejects testimonies that are too short to be meaningful or
+// longer than the site will display.
+type LengthRule struct {
+	Min   int
+	Max   int
+	Label string // Names the content in reasons; defaults to "testimony"
+}
+
+func (r LengthRule) Name() string { return "length" }
+
+func (r LengthRule) Check(ctx context.Context, sub *Submission) (Verdict, string, error) {
+	label := r.Label
+	if label == "" {
+		label = "testimony"
+	}
+	length := utf8.RuneCountInString(strings.TrimSpace(sub.Testimony))
+	if r.Min > 0 && length < r.Min {
+		return Reject, fmt.Sprintf("%s must be at least %d characters", label, r.Min), nil
+	}
+	if r.Max > 0 && length > r.Max {
+		return Reject, fmt.Sprintf("%s must be at most %d characters", label, r.Max), nil
+	}
+	return Allow, "", nil
+}
+
+// BlocklistRule flags submissions containing any blocked word or phrase.
+// Matching is case-insensitive and on word boundaries.
+type BlocklistRule struct {
+	pattern *regexp.Regexp
+}
+
+func NewBlocklistRule(terms []string) *BlocklistRule {
+	quoted := make([]string, 0, len(terms))
+	for _, term := range terms {
+		if term = strings.TrimSpace(term); term != "" {
+			quoted = append(quoted, regexp.QuoteMeta(term))
+		}
+	}
+	if len(quoted) == 0 {
+		return &BlocklistRule{}
+	}
+	return &BlocklistRule{
+		pattern: regexp.MustCompile(`(?i)\b(` + strings.Join(quoted, "|") + `)\b`),
+	}
+}
+
+func (r *BlocklistRule) Name() string { return "blocklist" }
+
+func (r *BlocklistRule) Check(ctx context.Context, sub *Submission) (Verdict, string, error) {
+	if r.pattern == nil {
+		return Allow, "", nil
+	}
+	for _, field := range []string{sub.FirstName, sub.LastName, sub.Testimony} {
+		if match := r.pattern.FindString(field); match != "" {
+			return Flag, fmt.Sprintf("contains blocked term %q", strings.ToLower(match)), nil
+		}
+	}
+	return Allow, "", nil
+}
+
+var linkPattern = regexp.MustCompile(`(?i)(https?://|www\.)\S+`)
+
+// LinkCountRule flags testimonies containing more links than allowed, the
+// most common sign of link spam.
+type LinkCountRule struct {
+	Max int
+}
+
+func (r LinkCountRule) Name() string { return "links" }
+
+func (r LinkCountRule) Check(ctx context.Context, sub *Submission) (Verdict, string, error) {
+	count := len(linkPattern.FindAllString(sub.Testimony, -1))
+	if count > r.Max {
+		return Flag, fmt.Sprintf("contains %d links (max %d)", count, r.Max), nil
+	}
+	return Allow, "", nil
+}
+
+// HoneypotRule rejects submissions that filled in a form field hidden from
+// humans; only bots complete it.
+type HoneypotRule struct{}
+
+func (HoneypotRule) Name() string { return "honeypot" }
+
+func (HoneypotRule) Check(ctx context.Context, sub *Submission) (Verdict, string, error) {
+	if strings.TrimSpace(sub.Honeypot) != "" {
+		return Reject, "hidden field was filled in", nil
+	}
+	return Allow, "", nil
+}
+
+// DuplicateChecker reports whether a testimony with the given content hash
+// has already been submitted.
+type DuplicateChecker interface {
+	ExistsByContentHash(ctx context.Context, hash string) (bool, error)
+}
+
+// DuplicateRule flags testimonies whose normalized text matches an existing one.
+type DuplicateRule struct {
+	checker DuplicateChecker
+}
+
+func NewDuplicateRule(checker DuplicateChecker) *DuplicateRule {
+	return &DuplicateRule{checker: checker}
+}
+
+func (r *DuplicateRule) Name() string { return "duplicate" }
+
+func (r *DuplicateRule) Check(ctx context.Context, sub *Submission) (Verdict, string, error) {
+	exists, err := r.checker.ExistsByContentHash(ctx, ContentHash(sub.Testimony))
+	if err != nil {
+		return Allow, "", err
+	}
+	if exists {
+		return Flag, "identical testimony already submitted", nil
+	}
+	return Allow, "", nil
+}
+
+// ContentHash fingerprints a testimony ignoring case and whitespace, so
+// trivially reformatted copies are still recognised as duplicates.
+func ContentHash(testimony string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(testimony)), " ")
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
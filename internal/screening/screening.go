@@ -0,0 +1,102 @@
+package screening
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrRejected is returned when a submission fails a rule outright.
+var ErrRejected = errors.New("submission rejected")
+
+// Verdict is the outcome of screening, ordered from least to most severe.
+type Verdict int
+
+const (
+	Allow Verdict = iota
+	Flag
+	Reject
+)
+
+func (v Verdict) String() string {
+	switch v {
+	case Allow:
+		return "allow"
+	case Flag:
+		return "flag"
+	case Reject:
+		return "reject"
+	default:
+		return fmt.Sprintf("verdict(%d)", int(v))
+	}
+}
+
+// Submission is the content screened before a public testimonial is stored.
+type Submission struct {
+	FirstName    string
+	LastName     string
+	Testimony    string
+	ImageURL     *string
+	Honeypot     string
+	CaptchaToken string
+	ClientIP     string
+}
+
+// Rule inspects a submission. It returns Allow with an empty reason when the
+// submission passes, or Flag/Reject with a reason for moderators.
+type Rule interface {
+	Name() string
+	Check(ctx context.Context, sub *Submission) (Verdict, string, error)
+}
+
+// Result is the combined outcome of every rule in a pipeline.
+type Result struct {
+	Verdict Verdict
+	Reasons []string
+}
+
+// Err returns an error wrapping ErrRejected when the verdict is Reject.
+func (r Result) Err() error {
+	if r.Verdict != Reject {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", ErrRejected, strings.Join(r.Reasons, "; "))
+}
+
+// Pipeline runs rules in order. A Reject stops the pipeline; Flags accumulate
+// so moderators see every reason a submission looked suspicious.
+type Pipeline struct {
+	rules []Rule
+}
+
+func NewPipeline(rules ...Rule) *Pipeline {
+	return &Pipeline{rules: rules}
+}
+
+func (p *Pipeline) Screen(ctx context.Context, sub *Submission) (Result, error) {
+	result := Result{Verdict: Allow}
+	if p == nil {
+		return result, nil
+	}
+
+	for _, rule := range p.rules {
+		verdict, reason, err := rule.Check(ctx, sub)
+		if err != nil {
+			return result, fmt.Errorf("screening rule %s failed: %w", rule.Name(), err)
+		}
+		if verdict == Allow {
+			continue
+		}
+
+		result.Reasons = append(result.Reasons, fmt.Sprintf("%s: %s", rule.Name(), reason))
+		if verdict > result.Verdict {
+			result.Verdict = verdict
+		}
+		if verdict == Reject {
+			break
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+    "context"
+    "log/slog"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/repository"
+    "wisdomHouse-backend/internal/screening"
+    "wisdomHouse-backend/internal/validation"
+    "wisdomHouse-backend/internal/worker/tasks"
+)
+
+// CommentService manages visitor comments on approved testimonials. Public
+// methods return models.PublicComment so commenters' email addresses are
+// never exposed; Admin methods return the full comment for moderators.
+type CommentService interface {
+    CreateComment(ctx context.Context, testimonialID uuid.UUID, req *models.CreateCommentRequest) (*models.PublicComment, error)
+    GetComments(ctx context.Context, testimonialID uuid.UUID, page, limit int) ([]models.PublicComment, int64, error)
+
+    AdminGetComments(ctx context.Context, page, limit int, filter models.CommentFilter) ([]models.Comment, int64, error)
+    ApproveComment(ctx context.Context, testimonialID, id uuid.UUID) (*models.Comment, error)
+    RejectComment(ctx context.Context, testimonialID, id uuid.UUID) (*models.Comment, error)
+    DeleteComment(ctx context.Context, testimonialID, id uuid.UUID) error
+}
+
+type commentService struct {
+    testimonials repository.TestimonialRepository
+    repo         repository.CommentRepository
+    uow          repository.UnitOfWork
+    screener     *screening.Pipeline
+    queue        TaskQueue
+    sender       tasks.Sender
+}
+
+// NewCommentService builds the service. sender may be nil, in which case
+// commenters are not notified by email.
+func NewCommentService(testimonials repository.TestimonialRepository, repo repository.CommentRepository, uow repository.UnitOfWork, screener *screening.Pipeline, queue TaskQueue, sender tasks.Sender) CommentService {
+    return &commentService{
+        testimonials: testimonials,
+        repo:         repo,
+        uow:          uow,
+        screener:     screener,
+        queue:        queue,
+        sender:       sender,
+    }
+}
+
+// CreateComment screens a comment or reply before storing it for moderation.
+// Replies must answer an approved top-level comment on the same testimonial.
+func (s *commentService) CreateComment(ctx context.Context, testimonialID uuid.UUID, req *models.CreateCommentRequest) (*models.PublicComment, error) {
+    if err := s.checkTestimonial(ctx, testimonialID); err != nil {
+        return nil, err
+    }
+    
+    if req.ParentID != nil {
+        parent, err := s.repo.GetByID(ctx, testimonialID, *req.ParentID)
+        if err != nil {
+            return nil, commentLookupError(err)
+        }
+        if !parent.IsApproved {
+            return nil, apperrors.NotFound(apperrors.CodeCommentNotFound, "Comment not found")
+        }
+        if parent.ParentID != nil {
+            return nil, apperrors.Unprocessable(apperrors.CodeInvalidReply, "Replies can only answer a top-level comment")
+        }
+    }
+    
+    if err := sanitizeCommentRequest(req); err != nil {
+        return nil, err
+    }
+    
+    result, err := s.screener.Screen(ctx, &screening.Submission{
+        FirstName:    req.AuthorName,
+        Testimony:    req.Body,
+        Honeypot:     req.Website,
+        CaptchaToken: req.CaptchaToken,
+        ClientIP:     req.ClientIP,
+    })
+    if err != nil {
+        return nil, err
+    }
+    if err := result.Err(); err != nil {
+        slog.InfoContext(ctx, "comment rejected by screening", "reasons", result.Reasons)
+        return nil, apperrors.Unprocessable(apperrors.CodeSubmissionRejected, "Comment rejected: "+strings.Join(result.Reasons, "; ")).Wrap(err)
+    }
+    
+    comment := &models.Comment{
+        TestimonialID: testimonialID,
+        ParentID:      req.ParentID,
+        AuthorName:    req.AuthorName,
+        AuthorEmail:   req.AuthorEmail,
+        Body:          req.Body,
+    }
+    
+    if result.Verdict == screening.Flag {
+        reasons := strings.Join(result.Reasons, "; ")
+        comment.IsFlagged = true
+        comment.FlagReasons = &reasons
+        slog.InfoContext(ctx, "comment flagged for review", "reasons", result.Reasons)
+    }
+    
+    if err := s.repo.Create(ctx, comment); err != nil {
+        return nil, err
+    }
+    
+    public := comment.ToPublic()
+    return &public, nil
+}
+
+// GetComments pages through a testimonial's approved comment threads.
+func (s *commentService) GetComments(ctx context.Context, testimonialID uuid.UUID, page, limit int) ([]models.PublicComment, int64, error) {
+    if err := s.checkTestimonial(ctx, testimonialID); err != nil {
+        return nil, 0, err
+    }
+    
+    page, limit = normalizePage(page, limit)
+    comments, total, err := s.repo.GetApprovedThreadsPaginated(ctx, testimonialID, page, limit)
+    if err != nil {
+        return nil, 0, err
+    }
+    
+    public := make([]models.PublicComment, 0, len(comments))
+    for i := range comments {
+        public = append(public, comments[i].ToPublic())
+    }
+    return public, total, nil
+}
+
+func (s *commentService) AdminGetComments(ctx context.Context, page, limit int, filter models.CommentFilter) ([]models.Comment, int64, error) {
+    page, limit = normalizePage(page, limit)
+    return s.repo.GetPaginated(ctx, page, limit, filter)
+}
+
+// ApproveComment publishes a comment and, the first time it is approved,
+// emails its author and, for replies, the author of the comment answered.
+// The comment is locked while it changes so concurrent approvals notify once.
+func (s *commentService) ApproveComment(ctx context.Context, testimonialID, id uuid.UUID) (*models.Comment, error) {
+    var comment *models.Comment
+    var wasApproved bool
+    err := s.uow.Do(ctx, func(ctx context.Context) error {
+        var err error
+        comment, err = s.repo.GetByIDForUpdate(ctx, testimonialID, id)
+        if err != nil {
+            return commentLookupError(err)
+        }
+        
+        wasApproved = comment.IsApproved
+        comment.IsApproved = true
+        comment.IsFlagged = false // Approval is the moderator's review of any flags
+        comment.RejectedAt = nil
+        return s.repo.Update(ctx, comment)
+    })
+    if err != nil {
+        return nil, err
+    }
+    
+    if !wasApproved {
+        s.notifyApproved(ctx, comment)
+    }
+    return comment, nil
+}
+
+// RejectComment hides a comment while keeping it for the moderation record.
+func (s *commentService) RejectComment(ctx context.Context, testimonialID, id uuid.UUID) (*models.Comment, error) {
+    var comment *models.Comment
+    err := s.uow.Do(ctx, func(ctx context.Context) error {
+        var err error
+        comment, err = s.repo.GetByIDForUpdate(ctx, testimonialID, id)
+        if err != nil {
+            return commentLookupError(err)
+        }
+        
+        now := time.Now()
+        comment.IsApproved = false
+        comment.RejectedAt = &now
+        return s.repo.Update(ctx, comment)
+    })
+    if err != nil {
+        return nil, err
+    }
+    return comment, nil
+}
+
+// DeleteComment soft-deletes a comment; its replies are hidden with it.
+func (s *commentService) DeleteComment(ctx context.Context, testimonialID, id uuid.UUID) error {
+    if _, err := s.repo.GetByID(ctx, testimonialID, id); err != nil {
+        return commentLookupError(err)
+    }
+    return s.repo.Delete(ctx, id)
+}
+
+// checkTestimonial hides comments on testimonials that are not public.
+func (s *commentService) checkTestimonial(ctx context.Context, testimonialID uuid.UUID) error {
+    testimonial, err := s.testimonials.GetByID(ctx, testimonialID)
+    if err != nil {
+        return testimonialLookupError(err)
+    }
+    if !testimonial.IsApproved {
+        return apperrors.NotFound(apperrors.CodeTestimonialNotFound, "Testimonial not found")
+    }
+    return nil
+}
+
+// notifyApproved queues the notification emails. Failures are logged rather
+// than returned: the comment is already published.
+func (s *commentService) notifyApproved(ctx context.Context, comment *models.Comment) {
+    if s.sender == nil {
+        return
+    }
+    
+    if comment.AuthorEmail != nil {
+        s.submitEmail(ctx, tasks.NewCommentApprovedEmailTask(ctx, s.sender, *comment.AuthorEmail, comment.AuthorName))
+    }
+    
+    if comment.ParentID == nil {
+        return
+    }
+    parent, err := s.repo.GetByID(ctx, comment.TestimonialID, *comment.ParentID)
+    if err != nil {
+        slog.WarnContext(ctx, "failed to load parent comment for reply notification", "comment_id", comment.ID, "error", err)
+        return
+    }
+    if parent.AuthorEmail != nil && !strings.EqualFold(*parent.AuthorEmail, stringValue(comment.AuthorEmail)) {
+        s.submitEmail(ctx, tasks.NewCommentReplyEmailTask(ctx, s.sender, *parent.AuthorEmail, parent.AuthorName, comment.AuthorName, comment.Body))
+    }
+}
+
+func (s *commentService) submitEmail(ctx context.Context, task *tasks.EmailTask) {
+    if err := s.queue.SubmitWithTimeout(ctx, task, taskSubmitTimeout); err != nil {
+        slog.WarnContext(ctx, "failed to queue comment notification", "error", err)
+    }
+}
+
+// sanitizeCommentRequest strips all markup; comments are plain text.
+func sanitizeCommentRequest(req *models.CreateCommentRequest) error {
+    req.AuthorName = validation.SanitizeText(req.AuthorName)
+    req.Body = validation.SanitizeText(req.Body)
+    
+    switch {
+    case req.AuthorName == "":
+        return emptyContentError("authorName")
+    case req.Body == "":
+        return emptyContentError("body")
+    }
+    if req.AuthorEmail != nil && strings.TrimSpace(*req.AuthorEmail) == "" {
+        req.AuthorEmail = nil
+    }
+    return nil
+}
+
+func stringValue(s *string) string {
+    if s == nil {
+        return ""
+    }
+    return *s
+}
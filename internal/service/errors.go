@@ -0,0 +1,125 @@
+package service
+
+import (
+    "errors"
+    "fmt"
+
+    "gorm.io/gorm"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/media"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/repository"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+// testimonialLookupError translates a repository lookup failure into a
+// domain error, leaving unexpected failures to be reported as internal.
+func testimonialLookupError(err error) error {
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return apperrors.NotFound(apperrors.CodeTestimonialNotFound, "Testimonial not found").Wrap(err)
+    }
+    return err
+}
+
+// trashLookupError is testimonialLookupError for operations that only apply
+// to soft-deleted testimonials.
+func trashLookupError(err error) error {
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return apperrors.NotFound(apperrors.CodeTestimonialNotFound, "Testimonial not found in trash").Wrap(err)
+    }
+    return err
+}
+
+func revisionLookupError(err error) error {
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return apperrors.NotFound(apperrors.CodeRevisionNotFound, "Revision not found").Wrap(err)
+    }
+    return err
+}
+
+func commentLookupError(err error) error {
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return apperrors.NotFound(apperrors.CodeCommentNotFound, "Comment not found").Wrap(err)
+    }
+    return err
+}
+
+func translationLookupError(err error) error {
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return apperrors.NotFound(apperrors.CodeTranslationNotFound, "Translation not found").Wrap(err)
+    }
+    return err
+}
+
+// translatedLanguageError reports a language that would be both a
+// testimonial's original and one of its translations.
+func translatedLanguageError() error {
+    return apperrors.Conflict(apperrors.CodeTranslationIsOriginal, "A testimonial cannot have a translation in its original language")
+}
+
+// testimonialUpdateError reports a lost optimistic-concurrency race as a
+// conflict the client can resolve by fetching the testimonial again.
+func testimonialUpdateError(err error) error {
+    if errors.Is(err, repository.ErrVersionConflict) {
+        return apperrors.Conflict(apperrors.CodeVersionConflict, "Testimonial was modified concurrently; fetch it again and retry").Wrap(err)
+    }
+    return err
+}
+
+// checkVersion enforces the version the client based its change on. Zero
+// means the client accepted any version (If-Match: *).
+func checkVersion(testimonial *models.Testimonial, expected int64) error {
+    if expected != 0 && testimonial.Version != expected {
+        return apperrors.PreconditionFailed(apperrors.CodeVersionMismatch,
+            fmt.Sprintf("Testimonial has changed (version %d, expected %d); fetch it again and retry", testimonial.Version, expected))
+    }
+    return nil
+}
+
+// notApprovedError reports an action that is only allowed on approved
+// testimonials, such as featuring one on the home page.
+func notApprovedError() error {
+    return apperrors.Conflict(apperrors.CodeNotApproved, "Testimonial must be approved first")
+}
+
+// reactionsUnavailableError reports that reactions cannot be deduplicated
+// because Redis is disabled or unreachable.
+func reactionsUnavailableError(err error) error {
+    return apperrors.Unavailable(apperrors.CodeReactionsUnavailable, "Reactions are temporarily unavailable").Wrap(err)
+}
+
+// queueUnavailableError reports that background work could not be queued
+// because the worker pool is saturated or shutting down.
+func queueUnavailableError(err error) error {
+    return apperrors.Unavailable(apperrors.CodeQueueUnavailable, "Background jobs are temporarily unavailable; try again later").Wrap(err)
+}
+
+func mediaLookupError(err error) error {
+    if errors.Is(err, gorm.ErrRecordNotFound) {
+        return apperrors.NotFound(apperrors.CodeMediaNotFound, "Media not found").Wrap(err)
+    }
+    return err
+}
+
+// uploadError translates media processing failures into domain errors.
+func uploadError(err error) error {
+    switch {
+    case errors.Is(err, media.ErrImageTooLarge), errors.Is(err, media.ErrMediaTooLarge):
+        return apperrors.TooLarge(apperrors.CodeFileTooLarge, err.Error()).Wrap(err)
+    case errors.Is(err, media.ErrUnsupportedImageType), errors.Is(err, media.ErrUnsupportedMediaType):
+        return apperrors.UnsupportedMedia(apperrors.CodeUnsupportedFileType, err.Error()).Wrap(err)
+    case errors.Is(err, media.ErrInvalidImage):
+        return apperrors.Validation(apperrors.CodeInvalidImage, "Image could not be decoded").Wrap(err)
+    default:
+        return err
+    }
+}
+
+// emptyContentError reports a field that held nothing but markup.
+func emptyContentError(field string) error {
+    return apperrors.Validation(apperrors.CodeEmptyContent, "Validation failed", utils.FieldError{
+        Field:   field,
+        Rule:    "notblank",
+        Message: "is empty once markup is removed",
+    })
+}
\ No newline at end of file
@@ -0,0 +1,98 @@
+package service
+
+import (
+    "context"
+    "io"
+    "log/slog"
+    "time"
+
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/export"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/repository"
+    "wisdomHouse-backend/internal/storage"
+    "wisdomHouse-backend/internal/worker/tasks"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+// ExportService exports testimonials for moderators, either streamed in the
+// response or built in the background and delivered by email.
+type ExportService interface {
+    ExportTestimonials(ctx context.Context, format models.ExportFormat, filter models.TestimonialFilter, w io.Writer) error
+    QueueExport(ctx context.Context, req *models.ExportRequest) (*models.ExportJob, error)
+}
+
+type exportService struct {
+    repo    repository.TestimonialRepository
+    storage storage.Storage
+    queue   TaskQueue
+    sender  tasks.Sender
+    tempDir string
+    linkTTL time.Duration
+}
+
+// NewExportService builds the service. sender may be nil, in which case only
+// streamed exports are available. Emailed download links expire after
+// linkTTL.
+func NewExportService(repo repository.TestimonialRepository, store storage.Storage, queue TaskQueue, sender tasks.Sender, tempDir string, linkTTL time.Duration) ExportService {
+    return &exportService{
+        repo:    repo,
+        storage: store,
+        queue:   queue,
+        sender:  sender,
+        tempDir: tempDir,
+        linkTTL: linkTTL,
+    }
+}
+
+// ExportTestimonials writes every matching testimonial to w, oldest first.
+// Nothing reaches w until the first batch has been loaded, so lookup
+// failures can still be reported as an error response.
+func (s *exportService) ExportTestimonials(ctx context.Context, format models.ExportFormat, filter models.TestimonialFilter, w io.Writer) error {
+    if err := checkDateRange(filter.From, filter.To); err != nil {
+        return err
+    }
+    
+    count, err := export.Write(ctx, s.repo, format, filter, w, export.Options{GeneratedAt: time.Now()})
+    if err != nil {
+        return err
+    }
+    slog.InfoContext(ctx, "testimonials exported", "format", format, "testimonials", count)
+    return nil
+}
+
+// QueueExport hands the export to the worker pool; the requester is emailed
+// a short-lived download link when it is ready.
+func (s *exportService) QueueExport(ctx context.Context, req *models.ExportRequest) (*models.ExportJob, error) {
+    if s.sender == nil {
+        return nil, apperrors.Unavailable(apperrors.CodeEmailUnavailable, "Email delivery is not configured; download the export directly instead")
+    }
+    if err := checkDateRange(req.From, req.To); err != nil {
+        return nil, err
+    }
+    
+    job := &models.ExportJob{
+        ID:       uuid.New(),
+        Format:   req.Format,
+        Email:    req.Email,
+        QueuedAt: time.Now(),
+    }
+    
+    task := tasks.NewExportTask(ctx, s.repo, s.storage, s.sender, s.tempDir, s.linkTTL, job.ID, req)
+    if err := s.queue.SubmitWithTimeout(ctx, task, taskSubmitTimeout); err != nil {
+        return nil, queueUnavailableError(err)
+    }
+    return job, nil
+}
+
+func checkDateRange(from, to *time.Time) error {
+    if from != nil && to != nil && !to.After(*from) {
+        return apperrors.Validation(apperrors.CodeValidationFailed, "Validation failed", utils.FieldError{
+            Field:   "to",
+            Rule:    "gtfield",
+            Message: "must be after from",
+        })
+    }
+    return nil
+}
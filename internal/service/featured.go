@@ -0,0 +1,159 @@
+package service
+
+import (
+    "context"
+    "log/slog"
+    "time"
+
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/cache"
+    "wisdomHouse-backend/internal/i18n"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+// featuredCacheKey holds the public featured list as []cachedFeature.
+const featuredCacheKey = "testimonials:featured:v2"
+
+// cachedFeature is a carousel entry as cached: the public testimonial in its
+// original language with its translations, so one entry serves every caller
+// and is localized on the way out.
+type cachedFeature struct {
+    Testimonial  models.PublicTestimonial        `json:"testimonial"`
+    Translations []models.TestimonialTranslation `json:"translations,omitempty"`
+}
+
+// Cache stores rendered responses; *cache.RedisClient satisfies it.
+type Cache interface {
+    GetJSON(ctx context.Context, key string, dest interface{}) error
+    SetJSON(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+    Delete(ctx context.Context, key string) error
+}
+
+// FeaturedOptions configures the home page carousel. A nil Cache or a zero
+// TTL disables caching.
+type FeaturedOptions struct {
+    Cache Cache
+    Limit int
+    TTL   time.Duration
+}
+
+// GetFeaturedTestimonials returns the carousel: approved, featured
+// testimonials inside their feature window, in manual order. The list is
+// cached until the next scheduled start or end, capped at the configured TTL,
+// and dropped whenever a moderator changes a testimonial. Cache failures are
+// logged and the list is served from the database. Each testimonial is
+// served in the caller's best language.
+func (s *testimonialService) GetFeaturedTestimonials(ctx context.Context) ([]models.PublicTestimonial, error) {
+    if s.featured.Cache != nil {
+        var cached []cachedFeature
+        err := s.featured.Cache.GetJSON(ctx, featuredCacheKey, &cached)
+        if err == nil {
+            return localizeFeatured(ctx, cached), nil
+        }
+        if !cache.IsMiss(err) {
+            slog.WarnContext(ctx, "featured cache read failed", "error", err)
+        }
+    }
+    
+    now := time.Now()
+    testimonials, err := s.repo.GetFeatured(ctx, now, s.featured.Limit)
+    if err != nil {
+        return nil, err
+    }
+    featured := make([]cachedFeature, 0, len(testimonials))
+    for i := range testimonials {
+        featured = append(featured, cachedFeature{
+            Testimonial:  testimonials[i].ToPublic(),
+            Translations: testimonials[i].Translations,
+        })
+    }
+    
+    if s.featured.Cache != nil && s.featured.TTL > 0 {
+        ttl, err := s.featuredTTL(ctx, now)
+        if err != nil {
+            return nil, err
+        }
+        if err := s.featured.Cache.SetJSON(ctx, featuredCacheKey, featured, ttl); err != nil {
+            slog.WarnContext(ctx, "featured cache write failed", "error", err)
+        }
+    }
+    return localizeFeatured(ctx, featured), nil
+}
+
+func localizeFeatured(ctx context.Context, featured []cachedFeature) []models.PublicTestimonial {
+    prefs := i18n.Preferences(ctx)
+    public := make([]models.PublicTestimonial, 0, len(featured))
+    for _, entry := range featured {
+        entry.Testimonial.Localize(entry.Translations, prefs)
+        public = append(public, entry.Testimonial)
+    }
+    return public
+}
+
+// featuredTTL caps the cache lifetime at the next scheduled feature change so
+// a testimonial appears or drops out on time.
+func (s *testimonialService) featuredTTL(ctx context.Context, now time.Time) (time.Duration, error) {
+    next, err := s.repo.NextFeatureChange(ctx, now)
+    if err != nil {
+        return 0, err
+    }
+    ttl := s.featured.TTL
+    if next != nil && next.Sub(now) < ttl {
+        ttl = max(next.Sub(now), time.Second)
+    }
+    return ttl, nil
+}
+
+// FeatureTestimonial replaces a testimonial's carousel settings. Only approved
+// testimonials can be featured.
+func (s *testimonialService) FeatureTestimonial(ctx context.Context, id uuid.UUID, version int64, req *models.FeatureTestimonialRequest) (*models.AdminTestimonial, error) {
+    if req.FeaturedFrom != nil && req.FeaturedUntil != nil && !req.FeaturedUntil.After(*req.FeaturedFrom) {
+        return nil, apperrors.Validation(apperrors.CodeValidationFailed, "Validation failed", utils.FieldError{
+            Field:   "featuredUntil",
+            Rule:    "gtfield",
+            Message: "must be after featuredFrom",
+        })
+    }
+    
+    var testimonial *models.Testimonial
+    err := s.uow.Do(ctx, func(ctx context.Context) error {
+        var err error
+        testimonial, err = s.repo.GetByIDForUpdate(ctx, id)
+        if err != nil {
+            return testimonialLookupError(err)
+        }
+        if err := checkVersion(testimonial, version); err != nil {
+            return err
+        }
+        if req.IsFeatured && !testimonial.IsApproved {
+            return notApprovedError()
+        }
+        
+        before := *testimonial
+        testimonial.IsFeatured = req.IsFeatured
+        testimonial.FeatureOrder = req.FeatureOrder
+        testimonial.FeaturedFrom = req.FeaturedFrom
+        testimonial.FeaturedUntil = req.FeaturedUntil
+        return s.save(ctx, &before, testimonial, models.RevisionFeature)
+    })
+    if err != nil {
+        return nil, err
+    }
+    
+    s.invalidateFeatured(ctx)
+    admin := testimonial.ToAdmin()
+    return &admin, nil
+}
+
+// invalidateFeatured drops the cached carousel after a change that may
+// affect it. A failure is only logged: the entry still expires with its TTL.
+func (s *testimonialService) invalidateFeatured(ctx context.Context) {
+    if s.featured.Cache == nil {
+        return
+    }
+    if err := s.featured.Cache.Delete(ctx, featuredCacheKey); err != nil {
+        slog.WarnContext(ctx, "featured cache invalidation failed", "error", err)
+    }
+}
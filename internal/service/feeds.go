@@ -0,0 +1,139 @@
+package service
+
+import (
+    "context"
+    "io"
+    "net/url"
+    "strings"
+    "time"
+    "unicode/utf8"
+
+    "wisdomHouse-backend/internal/feed"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/repository"
+    "wisdomHouse-backend/internal/validation"
+)
+
+// feedTitleLength bounds item titles, which are taken from the testimony.
+const feedTitleLength = 80
+
+// FeedOptions describes the public feeds; see config.FeedsConfig.
+type FeedOptions struct {
+    Title       string
+    Description string
+    SiteURL     string
+    BaseURL     string
+    Limit       int
+}
+
+// FeedService syndicates the newest approved testimonials, optionally those
+// carrying one tag. Anonymous testimonies are credited to
+// models.AnonymousDisplayName and never carry a photo.
+type FeedService interface {
+    LastModified(ctx context.Context) (time.Time, error)
+    WriteFeed(ctx context.Context, format models.FeedFormat, tag string, w io.Writer) error
+}
+
+type feedService struct {
+    repo    repository.TestimonialRepository
+    options FeedOptions
+}
+
+func NewFeedService(repo repository.TestimonialRepository, options FeedOptions) FeedService {
+    options.SiteURL = strings.TrimSuffix(options.SiteURL, "/")
+    options.BaseURL = strings.TrimSuffix(options.BaseURL, "/")
+    return &feedService{repo: repo, options: options}
+}
+
+// LastModified reports when the feeds last could have changed, truncated to
+// the second as HTTP dates are. It is zero when there are no testimonials.
+func (s *feedService) LastModified(ctx context.Context) (time.Time, error) {
+    last, err := s.repo.LastModified(ctx)
+    if err != nil || last == nil {
+        return time.Time{}, err
+    }
+    return last.UTC().Truncate(time.Second), nil
+}
+
+// WriteFeed renders the feed served at FeedPath(format, tag) to w. Nothing
+// is written if the testimonials cannot be loaded.
+func (s *feedService) WriteFeed(ctx context.Context, format models.FeedFormat, tag string, w io.Writer) error {
+    f, err := s.buildFeed(ctx, format, tag)
+    if err != nil {
+        return err
+    }
+    return feed.Write(w, format, f)
+}
+
+// buildFeed lists the newest approved testimonials, each in the caller's
+// best language.
+func (s *feedService) buildFeed(ctx context.Context, format models.FeedFormat, tag string) (*feed.Feed, error) {
+    filter := models.TestimonialFilter{ApprovedOnly: true, Tag: tag, Sort: models.SortNewest}
+    testimonials, _, err := s.repo.GetPaginated(ctx, 1, s.options.Limit, filter)
+    if err != nil {
+        return nil, err
+    }
+    
+    f := &feed.Feed{
+        Title:       s.options.Title,
+        Description: s.options.Description,
+        SiteURL:     s.options.SiteURL,
+        FeedURL:     s.options.BaseURL + FeedPath(format, tag),
+        Items:       make([]feed.Item, 0, len(testimonials)),
+    }
+    if tag != "" {
+        f.Title += " #" + tag
+        f.SiteURL += "/testimonials?tag=" + url.QueryEscape(tag)
+    }
+    
+    for i := range testimonials {
+        testimonial := &testimonials[i]
+        public := toPublic(ctx, testimonial)
+        paragraphs := validation.PlainParagraphs(public.Testimony)
+        item := feed.Item{
+            ID:          "urn:uuid:" + public.ID.String(),
+            URL:         s.options.SiteURL + "/testimonials/" + public.ID.String(),
+            Title:       excerpt(paragraphs, feedTitleLength),
+            Author:      public.DisplayName,
+            ContentHTML: public.Testimony,
+            ContentText: strings.Join(paragraphs, "\n\n"),
+            Language:    public.Language,
+            Categories:  testimonial.Tags,
+            Published:   testimonial.CreatedAt,
+            Updated:     testimonial.UpdatedAt,
+        }
+        if public.ImageURL != nil {
+            item.ImageURL = *public.ImageURL
+        }
+        if testimonial.UpdatedAt.After(f.Updated) {
+            f.Updated = testimonial.UpdatedAt
+        }
+        f.Items = append(f.Items, item)
+    }
+    return f, nil
+}
+
+// FeedPath is the route a feed is served at, relative to the API root.
+func FeedPath(format models.FeedFormat, tag string) string {
+    if tag == "" {
+        return "/feeds/testimonials." + string(format)
+    }
+    return "/feeds/tags/" + url.PathEscape(tag) + "/testimonials." + string(format)
+}
+
+// excerpt shortens the first paragraph to at most limit characters, cutting
+// at a word boundary where there is one.
+func excerpt(paragraphs []string, limit int) string {
+    if len(paragraphs) == 0 {
+        return ""
+    }
+    text := paragraphs[0]
+    if utf8.RuneCountInString(text) <= limit {
+        return text
+    }
+    cut := string([]rune(text)[:limit-1])
+    if i := strings.LastIndexByte(cut, ' '); i > 0 {
+        cut = cut[:i]
+    }
+    return strings.TrimRight(cut, " ,;:.") + "…"
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "io"
+
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/audit"
+    "wisdomHouse-backend/internal/importer"
+    "wisdomHouse-backend/internal/logging"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/repository"
+    "wisdomHouse-backend/internal/screening"
+    "wisdomHouse-backend/internal/validation"
+    "wisdomHouse-backend/pkg/utils"
+)
+
+// importBatchSize is how many testimonials each INSERT statement carries.
+const importBatchSize = 500
+
+// ImportService loads testimonials collected before the API existed.
+type ImportService interface {
+    ImportTestimonials(ctx context.Context, format models.ImportFormat, r io.Reader, dryRun bool) (*models.ImportResult, error)
+}
+
+type importService struct {
+    repo      repository.TestimonialRepository
+    revisions repository.RevisionRepository
+    uow       repository.UnitOfWork
+}
+
+func NewImportService(repo repository.TestimonialRepository, revisions repository.RevisionRepository, uow repository.UnitOfWork) ImportService {
+    return &importService{
+        repo:      repo,
+        revisions: revisions,
+        uow:       uow,
+    }
+}
+
+// ImportTestimonials validates every row, skips invalid rows and testimonies
+// that already exist or appear earlier in the file, and inserts the rest in
+// one transaction. A dry run reports the same outcome without writing.
+func (s *importService) ImportTestimonials(ctx context.Context, format models.ImportFormat, r io.Reader, dryRun bool) (*models.ImportResult, error) {
+    records, err := importer.Decode(format, r)
+    if err != nil {
+        if errors.Is(err, importer.ErrInvalidFile) {
+            return nil, apperrors.Validation(apperrors.CodeInvalidImport, err.Error()).Wrap(err)
+        }
+        return nil, err
+    }
+    
+    result := &models.ImportResult{
+        DryRun: dryRun,
+        Total:  len(records),
+        Rows:   make([]models.ImportRowResult, len(records)),
+    }
+    
+    // Testimonials to insert, and the index of the row each came from
+    var pending []models.Testimonial
+    var pendingRows []int
+    seen := make(map[string]int, len(records))
+    for i := range records {
+        row := &result.Rows[i]
+        row.Row = i + 1
+        
+        testimonial, fields := newImportedTestimonial(&records[i])
+        if fields != nil {
+            row.Status = models.ImportInvalid
+            row.Message = "Validation failed"
+            row.Errors = fields
+            result.Invalid++
+            continue
+        }
+        if first, ok := seen[testimonial.ContentHash]; ok {
+            row.Status = models.ImportDuplicate
+            row.Message = fmt.Sprintf("Same testimony as row %d", first)
+            result.Duplicates++
+            continue
+        }
+        seen[testimonial.ContentHash] = row.Row
+        pending = append(pending, *testimonial)
+        pendingRows = append(pendingRows, i)
+    }
+    
+    hashes := make([]string, 0, len(pending))
+    for i := range pending {
+        hashes = append(hashes, pending[i].ContentHash)
+    }
+    existing, err := s.repo.ExistingContentHashes(ctx, hashes)
+    if err != nil {
+        return nil, err
+    }
+    
+    var toCreate []models.Testimonial
+    var createdRows []int
+    for i := range pending {
+        row := &result.Rows[pendingRows[i]]
+        if existing[pending[i].ContentHash] {
+            row.Status = models.ImportDuplicate
+            row.Message = "Identical testimony already exists"
+            result.Duplicates++
+            continue
+        }
+        row.Status = models.ImportCreated
+        toCreate = append(toCreate, pending[i])
+        createdRows = append(createdRows, pendingRows[i])
+    }
+    result.Created = len(toCreate)
+    
+    if dryRun || len(toCreate) == 0 {
+        return result, nil
+    }
+    
+    err = s.uow.Do(ctx, func(ctx context.Context) error {
+        for start := 0; start < len(toCreate); start += importBatchSize {
+            batch := toCreate[start:min(start+importBatchSize, len(toCreate))]
+            if err := s.repo.CreateBatch(ctx, batch); err != nil {
+                return err
+            }
+            
+            revisions := make([]models.TestimonialRevision, 0, len(batch))
+            for i := range batch {
+                revisions = append(revisions, *models.NewRevision(&models.Testimonial{}, &batch[i], models.RevisionImport, audit.Actor(ctx), logging.RequestID(ctx)))
+            }
+            if err := s.revisions.CreateBatch(ctx, revisions); err != nil {
+                return err
+            }
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    
+    for i, rowIndex := range createdRows {
+        id := toCreate[i].ID
+        result.Rows[rowIndex].ID = &id
+    }
+    return result, nil
+}
+
+// newImportedTestimonial applies the public submission rules to a row. It
+// returns the offending fields instead when the row is invalid.
+func newImportedTestimonial(record *importer.Record) (*models.Testimonial, []utils.FieldError) {
+    if len(record.Errors) > 0 {
+        return nil, record.Errors
+    }
+    
+    row := &record.Row
+    req := row.CreateRequest()
+    if err := validation.Validate(&req); err != nil {
+        return nil, apperrors.FromBinding(err).Fields
+    }
+    if err := validation.Validate(row); err != nil {
+        return nil, apperrors.FromBinding(err).Fields
+    }
+    if err := sanitizeCreateRequest(&req); err != nil {
+        return nil, apperrors.As(err).Fields
+    }
+    tags, err := normalizeTags(row.Tags)
+    if err != nil {
+        return nil, apperrors.As(err).Fields
+    }
+    
+    testimonial := &models.Testimonial{
+        ID:          uuid.New(),
+        FirstName:   req.FirstName,
+        LastName:    req.LastName,
+        FullName:    fmt.Sprintf("%s %s", req.FirstName, req.LastName),
+        ImageURL:    req.ImageURL,
+        Testimony:   req.Testimony,
+        Language:    req.Language,
+        IsAnonymous: req.IsAnonymous,
+        IsApproved:  row.IsApproved,
+        Tags:        models.Tags(nil).Add(tags...),
+        ContentHash: screening.ContentHash(req.Testimony),
+        Version:     1,
+    }
+    if row.CreatedAt != nil {
+        testimonial.CreatedAt = *row.CreatedAt
+    }
+    return testimonial, nil
+}
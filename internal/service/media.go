@@ -0,0 +1,234 @@
+package service
+
+import (
+    "context"
+    "fmt"
+    "io"
+    "log/slog"
+    "os"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    "gorm.io/gorm"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/media"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/repository"
+    "wisdomHouse-backend/internal/screening"
+    "wisdomHouse-backend/internal/storage"
+    "wisdomHouse-backend/internal/validation"
+    "wisdomHouse-backend/internal/worker"
+    "wisdomHouse-backend/internal/worker/tasks"
+)
+
+// taskSubmitTimeout bounds how long an upload waits for room in the worker queue
+const taskSubmitTimeout = 5 * time.Second
+
+// TaskQueue accepts background work; satisfied by *worker.WorkerPool.
+type TaskQueue interface {
+    SubmitWithTimeout(ctx context.Context, task worker.Task, timeout time.Duration) error
+}
+
+// MediaService manages recordings attached to testimonials. Uploads are
+// public, so recordings only reach the public once a moderator approves them.
+type MediaService interface {
+    UploadTestimonialMedia(ctx context.Context, testimonialID uuid.UUID, sourcePath string, transcript *string) (*models.TestimonialMedia, error)
+    GetTestimonialMedia(ctx context.Context, testimonialID uuid.UUID) ([]models.TestimonialMedia, error)
+    UpdateTranscript(ctx context.Context, testimonialID, mediaID uuid.UUID, req *models.UpdateMediaTranscriptRequest) (*models.TestimonialMedia, error)
+    ApproveMedia(ctx context.Context, testimonialID, mediaID uuid.UUID) (*models.TestimonialMedia, error)
+    DeleteMedia(ctx context.Context, testimonialID, mediaID uuid.UUID) error
+}
+
+type mediaService struct {
+    testimonials  repository.TestimonialRepository
+    repo          repository.MediaRepository
+    storage       storage.Storage
+    transcoder    media.Transcoder
+    queue         TaskQueue
+    screener      *screening.Pipeline
+    maxMediaBytes int64
+}
+
+func NewMediaService(
+    testimonials repository.TestimonialRepository,
+    repo repository.MediaRepository,
+    store storage.Storage,
+    transcoder media.Transcoder,
+    queue TaskQueue,
+    screener *screening.Pipeline,
+    maxMediaBytes int64,
+) MediaService {
+    return &mediaService{
+        testimonials:  testimonials,
+        repo:          repo,
+        storage:       store,
+        transcoder:    transcoder,
+        queue:         queue,
+        screener:      screener,
+        maxMediaBytes: maxMediaBytes,
+    }
+}
+
+// UploadTestimonialMedia stores the original recording and queues it for
+// transcoding. A transcript is sanitized and screened like testimony text:
+// rejected ones fail the upload and suspicious ones are flagged for review.
+// It takes ownership of sourcePath: the file is removed on failure, or by
+// the transcode task once it has been processed.
+func (s *mediaService) UploadTestimonialMedia(ctx context.Context, testimonialID uuid.UUID, sourcePath string, transcript *string) (*models.TestimonialMedia, error) {
+    handedOff := false
+    defer func() {
+        if !handedOff {
+            os.Remove(sourcePath)
+        }
+    }()
+    
+    if _, err := s.testimonials.GetByID(ctx, testimonialID); err != nil {
+        return nil, testimonialLookupError(err)
+    }
+    
+    transcript = sanitizeTranscript(transcript)
+    var flagReasons *string
+    if transcript != nil {
+        result, err := s.screener.Screen(ctx, &screening.Submission{Testimony: *transcript})
+        if err != nil {
+            return nil, err
+        }
+        if err := result.Err(); err != nil {
+            slog.InfoContext(ctx, "transcript rejected by screening", "reasons", result.Reasons)
+            return nil, apperrors.Unprocessable(apperrors.CodeSubmissionRejected, "Transcript rejected: "+strings.Join(result.Reasons, "; ")).Wrap(err)
+        }
+        if result.Verdict == screening.Flag {
+            reasons := strings.Join(result.Reasons, "; ")
+            flagReasons = &reasons
+            slog.InfoContext(ctx, "transcript flagged for review", "reasons", result.Reasons)
+        }
+    }
+    
+    file, err := os.Open(sourcePath)
+    if err != nil {
+        return nil, fmt.Errorf("failed to open upload: %w", err)
+    }
+    defer file.Close()
+    
+    info, err := file.Stat()
+    if err != nil {
+        return nil, fmt.Errorf("failed to stat upload: %w", err)
+    }
+    if info.Size() > s.maxMediaBytes {
+        return nil, uploadError(media.ErrMediaTooLarge)
+    }
+    
+    mimeType, kind, extension, err := media.DetectMediaType(file)
+    if err != nil {
+        return nil, uploadError(err)
+    }
+    if _, err := file.Seek(0, io.SeekStart); err != nil {
+        return nil, err
+    }
+    
+    record := &models.TestimonialMedia{
+        ID:              uuid.New(),
+        TestimonialID:   testimonialID,
+        Kind:            kind,
+        MimeType:        mimeType,
+        SizeBytes:       info.Size(),
+        TranscodeStatus: models.TranscodePending,
+        Transcript:      transcript,
+        IsFlagged:       flagReasons != nil,
+        FlagReasons:     flagReasons,
+    }
+    
+    key := fmt.Sprintf("testimonials/%s/media/%s/original%s", testimonialID, record.ID, extension)
+    record.OriginalURL, err = s.storage.Put(ctx, key, file, info.Size(), mimeType)
+    if err != nil {
+        return nil, fmt.Errorf("failed to store media: %w", err)
+    }
+    
+    if err := s.repo.Create(ctx, record); err != nil {
+        return nil, err
+    }
+    
+    task := tasks.NewTranscodeTask(ctx, s.repo, s.storage, s.transcoder, record.ID, sourcePath)
+    if err := s.queue.SubmitWithTimeout(ctx, task, taskSubmitTimeout); err != nil {
+        slog.WarnContext(ctx, "failed to queue transcoding", "media_id", record.ID, "error", err)
+        message := fmt.Sprintf("failed to queue transcoding: %v", err)
+        record.TranscodeStatus = models.TranscodeFailed
+        record.TranscodeError = &message
+        if updateErr := s.repo.Update(ctx, record); updateErr != nil {
+            return nil, updateErr
+        }
+        return record, nil
+    }
+    handedOff = true
+    
+    return record, nil
+}
+
+func (s *mediaService) GetTestimonialMedia(ctx context.Context, testimonialID uuid.UUID) ([]models.TestimonialMedia, error) {
+    return s.repo.GetByTestimonial(ctx, testimonialID)
+}
+
+func (s *mediaService) UpdateTranscript(ctx context.Context, testimonialID, mediaID uuid.UUID, req *models.UpdateMediaTranscriptRequest) (*models.TestimonialMedia, error) {
+    record, err := s.getOwned(ctx, testimonialID, mediaID)
+    if err != nil {
+        return nil, err
+    }
+    
+    record.Transcript = sanitizeTranscript(req.Transcript)
+    if err := s.repo.Update(ctx, record); err != nil {
+        return nil, err
+    }
+    
+    return record, nil
+}
+
+// ApproveMedia makes a recording and its transcript public once transcoding
+// has finished.
+func (s *mediaService) ApproveMedia(ctx context.Context, testimonialID, mediaID uuid.UUID) (*models.TestimonialMedia, error) {
+    record, err := s.getOwned(ctx, testimonialID, mediaID)
+    if err != nil {
+        return nil, err
+    }
+    
+    if err := s.repo.Approve(ctx, mediaID); err != nil {
+        return nil, err
+    }
+    record.IsApproved = true
+    record.IsFlagged = false
+    record.FlagReasons = nil
+    return record, nil
+}
+
+func (s *mediaService) DeleteMedia(ctx context.Context, testimonialID, mediaID uuid.UUID) error {
+    if _, err := s.getOwned(ctx, testimonialID, mediaID); err != nil {
+        return err
+    }
+    return s.repo.Delete(ctx, mediaID)
+}
+
+// getOwned loads a media record, treating one attached to a different
+// testimonial as missing.
+func (s *mediaService) getOwned(ctx context.Context, testimonialID, mediaID uuid.UUID) (*models.TestimonialMedia, error) {
+    record, err := s.repo.GetByID(ctx, mediaID)
+    if err != nil {
+        return nil, mediaLookupError(err)
+    }
+    if record.TestimonialID != testimonialID {
+        return nil, mediaLookupError(gorm.ErrRecordNotFound)
+    }
+    return record, nil
+}
+
+// sanitizeTranscript cleans a transcript the way testimony text is cleaned,
+// treating one with no text left as absent.
+func sanitizeTranscript(transcript *string) *string {
+    if transcript == nil {
+        return nil
+    }
+    sanitized := validation.SanitizeHTML(*transcript)
+    if validation.SanitizeText(sanitized) == "" {
+        return nil
+    }
+    return &sanitized
+}
\ No newline at end of file
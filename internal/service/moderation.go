@@ -0,0 +1,114 @@
+package service
+
+import (
+    "context"
+    "errors"
+    "log/slog"
+    "strings"
+    "time"
+
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/validation"
+)
+
+// BulkModerate applies one moderation action to every requested testimonial
+// in a single transaction. Items that cannot be moderated (missing, or not
+// approved for featuring) are reported in the result without affecting the
+// rest; any unexpected failure rolls back the whole batch. Bulk actions skip
+// the If-Match version check: rows are locked one by one as they are changed.
+func (s *testimonialService) BulkModerate(ctx context.Context, req *models.BulkModerationRequest) (*models.BulkModerationResult, error) {
+    tags, err := normalizeTags(req.Tags)
+    if err != nil {
+        return nil, err
+    }
+    
+    var result *models.BulkModerationResult
+    err = s.uow.Do(ctx, func(ctx context.Context) error {
+        result = &models.BulkModerationResult{Action: req.Action, Results: make([]models.BulkItemResult, 0, len(req.IDs))}
+        for _, id := range req.IDs {
+            item := models.BulkItemResult{ID: id}
+            version, err := s.moderateOne(ctx, id, req.Action, tags)
+            
+            var appErr *apperrors.Error
+            switch {
+            case err == nil:
+                item.Success = true
+                item.Version = version
+                result.Succeeded++
+            case errors.As(err, &appErr) && appErr.Kind != apperrors.KindInternal:
+                item.Code = appErr.Code
+                item.Message = appErr.Message
+                result.Failed++
+            default:
+                return err
+            }
+            result.Results = append(result.Results, item)
+        }
+        return nil
+    })
+    if err != nil {
+        return nil, err
+    }
+    
+    s.invalidateFeatured(ctx)
+    slog.InfoContext(ctx, "bulk moderation applied",
+        "action", req.Action, "succeeded", result.Succeeded, "failed", result.Failed)
+    return result, nil
+}
+
+// moderateOne applies action to a single locked testimonial and returns its
+// new version, or 0 once it has been deleted.
+func (s *testimonialService) moderateOne(ctx context.Context, id uuid.UUID, action models.BulkAction, tags []string) (int64, error) {
+    testimonial, err := s.repo.GetByIDForUpdate(ctx, id)
+    if err != nil {
+        return 0, testimonialLookupError(err)
+    }
+    
+    before := *testimonial
+    switch action {
+    case models.BulkApprove:
+        testimonial.IsApproved = true
+        testimonial.IsFlagged = false // Approval is the moderator's review of any flags
+        testimonial.RejectedAt = nil
+    case models.BulkReject:
+        now := time.Now()
+        testimonial.IsApproved = false
+        testimonial.IsFlagged = false
+        testimonial.IsFeatured = false // A rejected testimonial must leave the carousel too
+        testimonial.RejectedAt = &now
+    case models.BulkDelete:
+        return 0, s.repo.Delete(ctx, id)
+    case models.BulkTag:
+        testimonial.Tags = testimonial.Tags.Add(tags...)
+    case models.BulkUntag:
+        testimonial.Tags = testimonial.Tags.Remove(tags...)
+    case models.BulkFeature:
+        if !testimonial.IsApproved {
+            return 0, notApprovedError()
+        }
+        testimonial.IsFeatured = true
+    case models.BulkUnfeature:
+        testimonial.IsFeatured = false
+    }
+    
+    if err := s.save(ctx, &before, testimonial, models.RevisionAction(action)); err != nil {
+        return 0, err
+    }
+    return testimonial.Version, nil
+}
+
+// normalizeTags lowercases tags and strips markup so "Healing" and
+// "<b>healing</b>" are the same tag.
+func normalizeTags(tags []string) ([]string, error) {
+    normalized := make([]string, 0, len(tags))
+    for _, tag := range tags {
+        tag = strings.ToLower(validation.SanitizeText(tag))
+        if tag == "" {
+            return nil, emptyContentError("tags")
+        }
+        normalized = append(normalized, tag)
+    }
+    return normalized, nil
+}
@@ -0,0 +1,139 @@
+package service
+
+import (
+    "context"
+    "crypto/hmac"
+    "crypto/sha256"
+    "encoding/hex"
+    "fmt"
+    "log/slog"
+    "time"
+
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/repository"
+)
+
+// ReactionSets records which visitors have reacted; *cache.RedisClient
+// satisfies it.
+type ReactionSets interface {
+    AddToSet(ctx context.Context, key, member string, expiration time.Duration) (bool, error)
+    RemoveFromSet(ctx context.Context, key, member string) (bool, error)
+}
+
+// ReactionService lets visitors react to approved testimonials at most once
+// per reaction kind.
+type ReactionService interface {
+    React(ctx context.Context, testimonialID uuid.UUID, kind models.ReactionKind, visitor models.Visitor) (*models.ReactionResult, error)
+    Unreact(ctx context.Context, testimonialID uuid.UUID, kind models.ReactionKind, visitor models.Visitor) (*models.ReactionResult, error)
+}
+
+type reactionService struct {
+    testimonials      repository.TestimonialRepository
+    repo              repository.ReactionRepository
+    sets              ReactionSets
+    fingerprintSecret []byte
+    dedupTTL          time.Duration
+}
+
+// NewReactionService builds the service. Deduplication needs sets; when it
+// is nil, reactions are reported as unavailable. A testimonial's set of
+// visitors expires dedupTTL after its last reaction.
+func NewReactionService(testimonials repository.TestimonialRepository, repo repository.ReactionRepository, sets ReactionSets, fingerprintSecret string, dedupTTL time.Duration) ReactionService {
+    return &reactionService{
+        testimonials:      testimonials,
+        repo:              repo,
+        sets:              sets,
+        fingerprintSecret: []byte(fingerprintSecret),
+        dedupTTL:          dedupTTL,
+    }
+}
+
+// React counts the visitor's reaction unless they already gave it. The
+// visitor is recorded in Redis first and removed again if the count cannot
+// be stored, so a failure never leaves them unable to retry.
+func (s *reactionService) React(ctx context.Context, testimonialID uuid.UUID, kind models.ReactionKind, visitor models.Visitor) (*models.ReactionResult, error) {
+    if err := s.checkAvailable(ctx, testimonialID); err != nil {
+        return nil, err
+    }
+    
+    key, member := reactionKey(testimonialID, kind), s.visitorKey(visitor)
+    added, err := s.sets.AddToSet(ctx, key, member, s.dedupTTL)
+    if err != nil {
+        return nil, reactionsUnavailableError(err)
+    }
+    if added {
+        if err := s.repo.Increment(ctx, testimonialID, kind); err != nil {
+            if _, undoErr := s.sets.RemoveFromSet(ctx, key, member); undoErr != nil {
+                slog.WarnContext(ctx, "failed to undo reaction after count failed", "testimonial_id", testimonialID, "error", undoErr)
+            }
+            return nil, err
+        }
+    }
+    return s.result(ctx, testimonialID, kind, added)
+}
+
+// Unreact withdraws the visitor's reaction if they gave it.
+func (s *reactionService) Unreact(ctx context.Context, testimonialID uuid.UUID, kind models.ReactionKind, visitor models.Visitor) (*models.ReactionResult, error) {
+    if err := s.checkAvailable(ctx, testimonialID); err != nil {
+        return nil, err
+    }
+    
+    key, member := reactionKey(testimonialID, kind), s.visitorKey(visitor)
+    removed, err := s.sets.RemoveFromSet(ctx, key, member)
+    if err != nil {
+        return nil, reactionsUnavailableError(err)
+    }
+    if removed {
+        if err := s.repo.Decrement(ctx, testimonialID, kind); err != nil {
+            if _, undoErr := s.sets.AddToSet(ctx, key, member, s.dedupTTL); undoErr != nil {
+                slog.WarnContext(ctx, "failed to restore reaction after count failed", "testimonial_id", testimonialID, "error", undoErr)
+            }
+            return nil, err
+        }
+    }
+    return s.result(ctx, testimonialID, kind, removed)
+}
+
+// checkAvailable rejects reactions when Redis is not configured and hides
+// testimonials that are not public.
+func (s *reactionService) checkAvailable(ctx context.Context, testimonialID uuid.UUID) error {
+    if s.sets == nil {
+        return reactionsUnavailableError(nil)
+    }
+    testimonial, err := s.testimonials.GetByID(ctx, testimonialID)
+    if err != nil {
+        return testimonialLookupError(err)
+    }
+    if !testimonial.IsApproved {
+        return apperrors.NotFound(apperrors.CodeTestimonialNotFound, "Testimonial not found")
+    }
+    return nil
+}
+
+func (s *reactionService) result(ctx context.Context, testimonialID uuid.UUID, kind models.ReactionKind, changed bool) (*models.ReactionResult, error) {
+    counts, err := s.repo.GetCounts(ctx, testimonialID)
+    if err != nil {
+        return nil, err
+    }
+    return &models.ReactionResult{
+        TestimonialID: testimonialID,
+        Kind:          kind,
+        Changed:       changed,
+        Reactions:     models.NewReactionCounts(counts),
+    }, nil
+}
+
+func reactionKey(testimonialID uuid.UUID, kind models.ReactionKind) string {
+    return fmt.Sprintf("reactions:%s:%s", testimonialID, kind)
+}
+
+// visitorKey hashes the visitor's connection fingerprint so IP addresses are
+// never stored in Redis. Nothing the client chooses freely goes into the key,
+// so a visitor cannot count twice by claiming a new identity.
+func (s *reactionService) visitorKey(visitor models.Visitor) string {
+    mac := hmac.New(sha256.New, s.fingerprintSecret)
+    mac.Write([]byte("fp:" + visitor.ClientIP + "|" + visitor.UserAgent))
+    return hex.EncodeToString(mac.Sum(nil))
+}
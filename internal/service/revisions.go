@@ -0,0 +1,75 @@
+package service
+
+import (
+    "context"
+    "fmt"
+
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/audit"
+    "wisdomHouse-backend/internal/logging"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/screening"
+)
+
+// save writes testimonial and records how it changed from before as a
+// revision attributed to the caller. It must run within s.uow.Do so the
+// change and its revision are committed together.
+func (s *testimonialService) save(ctx context.Context, before, testimonial *models.Testimonial, action models.RevisionAction) error {
+    if err := s.repo.Update(ctx, testimonial); err != nil {
+        return testimonialUpdateError(err)
+    }
+    return s.recordRevision(ctx, before, testimonial, action)
+}
+
+func (s *testimonialService) recordRevision(ctx context.Context, before, testimonial *models.Testimonial, action models.RevisionAction) error {
+    revision := models.NewRevision(before, testimonial, action, audit.Actor(ctx), logging.RequestID(ctx))
+    return s.revisions.Create(ctx, revision)
+}
+
+// GetTestimonialRevisions lists a testimonial's edit history, newest first.
+func (s *testimonialService) GetTestimonialRevisions(ctx context.Context, id uuid.UUID, page, limit int) ([]models.TestimonialRevision, int64, error) {
+    if _, err := s.repo.GetByID(ctx, id); err != nil {
+        return nil, 0, testimonialLookupError(err)
+    }
+    
+    page, limit = normalizePage(page, limit)
+    return s.revisions.GetByTestimonialPaginated(ctx, id, page, limit)
+}
+
+// RevertTestimonial restores the content a testimonial had at an earlier
+// revision. Moderation state (approval, flags, featuring, tags) is left as it
+// is; the revert itself is recorded as a new revision.
+func (s *testimonialService) RevertTestimonial(ctx context.Context, id, revisionID uuid.UUID, version int64) (*models.AdminTestimonial, error) {
+    var testimonial *models.Testimonial
+    err := s.uow.Do(ctx, func(ctx context.Context) error {
+        var err error
+        testimonial, err = s.repo.GetByIDForUpdate(ctx, id)
+        if err != nil {
+            return testimonialLookupError(err)
+        }
+        if err := checkVersion(testimonial, version); err != nil {
+            return err
+        }
+        
+        revision, err := s.revisions.GetByID(ctx, id, revisionID)
+        if err != nil {
+            return revisionLookupError(err)
+        }
+        
+        before := *testimonial
+        revision.Snapshot.RestoreContent(testimonial)
+        if testimonial.Translation(testimonial.Language) != nil {
+            return translatedLanguageError()
+        }
+        testimonial.FullName = fmt.Sprintf("%s %s", testimonial.FirstName, testimonial.LastName)
+        testimonial.ContentHash = screening.ContentHash(testimonial.Testimony)
+        return s.save(ctx, &before, testimonial, models.RevisionRevert)
+    })
+    if err != nil {
+        return nil, err
+    }
+    
+    s.invalidateFeatured(ctx)
+    admin := testimonial.ToAdmin()
+    return &admin, nil
+}
@@ -1,64 +1,372 @@
 package service
 
 import (
+    "bytes"
+    "context"
     "fmt"
+    "log/slog"
+    "net/url"
+    "strings"
 
     "github.com/google/uuid"
+    "wisdomHouse-backend/internal/apperrors"
+    "wisdomHouse-backend/internal/i18n"
+    "wisdomHouse-backend/internal/media"
     "wisdomHouse-backend/internal/models"        
     "wisdomHouse-backend/internal/repository"   
+    "wisdomHouse-backend/internal/screening"
+    "wisdomHouse-backend/internal/storage"
+    "wisdomHouse-backend/internal/validation"
 )
 
+// TestimonialService exposes testimonials to two audiences. Public methods
+// return models.PublicTestimonial so anonymous submitters are never revealed,
+// and public reads only ever see approved testimonials; Admin methods return
+// models.AdminTestimonial for moderators.
 type TestimonialService interface {
-    CreateTestimonial(req *models.CreateTestimonialRequest) (*models.Testimonial, error)
-    GetAllTestimonials(approved bool) ([]models.Testimonial, error)
-    GetTestimonialByID(id uuid.UUID) (*models.Testimonial, error)
-    UpdateTestimonial(id uuid.UUID, req *models.UpdateTestimonialRequest) (*models.Testimonial, error)
-    DeleteTestimonial(id uuid.UUID) error
-    GetPaginatedTestimonials(page, limit int, approved bool) ([]models.Testimonial, int64, error)
-    ApproveTestimonial(id uuid.UUID) (*models.Testimonial, error)
+    CreateTestimonial(ctx context.Context, req *models.CreateTestimonialRequest) (*models.PublicTestimonial, error)
+    GetAllTestimonials(ctx context.Context, filter models.TestimonialFilter) ([]models.PublicTestimonial, error)
+    GetTestimonialByID(ctx context.Context, id uuid.UUID) (*models.PublicTestimonial, error)
+    GetPaginatedTestimonials(ctx context.Context, page, limit int, filter models.TestimonialFilter) ([]models.PublicTestimonial, int64, error)
+    // UploadTestimonialImage lets a submitter add a photo while the testimonial awaits moderation
+    UploadTestimonialImage(ctx context.Context, id uuid.UUID, version int64, data []byte) (*models.PublicTestimonial, error)
+    GetFeaturedTestimonials(ctx context.Context) ([]models.PublicTestimonial, error)
+
+    AdminGetAllTestimonials(ctx context.Context, filter models.TestimonialFilter) ([]models.AdminTestimonial, error)
+    AdminGetTestimonialByID(ctx context.Context, id uuid.UUID) (*models.AdminTestimonial, error)
+    AdminGetPaginatedTestimonials(ctx context.Context, page, limit int, filter models.TestimonialFilter) ([]models.AdminTestimonial, int64, error)
+    // Mutations take the version the caller last saw; 0 skips the check.
+    UpdateTestimonial(ctx context.Context, id uuid.UUID, version int64, req *models.UpdateTestimonialRequest) (*models.AdminTestimonial, error)
+    DeleteTestimonial(ctx context.Context, id uuid.UUID, version int64) error
+    ApproveTestimonial(ctx context.Context, id uuid.UUID, version int64) (*models.AdminTestimonial, error)
+    BulkModerate(ctx context.Context, req *models.BulkModerationRequest) (*models.BulkModerationResult, error)
+    FeatureTestimonial(ctx context.Context, id uuid.UUID, version int64, req *models.FeatureTestimonialRequest) (*models.AdminTestimonial, error)
+    AdminUploadTestimonialImage(ctx context.Context, id uuid.UUID, version int64, data []byte) (*models.AdminTestimonial, error)
+    
+    // Translations: public endpoints serve the one matching Accept-Language
+    SaveTranslation(ctx context.Context, id uuid.UUID, version int64, language string, req *models.TranslationRequest) (*models.AdminTestimonial, error)
+    DeleteTranslation(ctx context.Context, id uuid.UUID, version int64, language string) (*models.AdminTestimonial, error)
+    
+    // Edit history: creation, edits, moderation and reverts are recorded as revisions
+    GetTestimonialRevisions(ctx context.Context, id uuid.UUID, page, limit int) ([]models.TestimonialRevision, int64, error)
+    RevertTestimonial(ctx context.Context, id, revisionID uuid.UUID, version int64) (*models.AdminTestimonial, error)
+
+    // Trash: DeleteTestimonial only soft-deletes
+    AdminGetDeletedTestimonials(ctx context.Context, page, limit int) ([]models.AdminTestimonial, int64, error)
+    RestoreTestimonial(ctx context.Context, id uuid.UUID) (*models.AdminTestimonial, error)
+    PurgeTestimonial(ctx context.Context, id uuid.UUID) error
 }
 
 type testimonialService struct {
-    repo repository.TestimonialRepository
+    repo          repository.TestimonialRepository
+    revisions     repository.RevisionRepository
+    uow           repository.UnitOfWork
+    storage       storage.Storage
+    screener      *screening.Pipeline
+    maxImageBytes int64
+    featured      FeaturedOptions
 }
 
-func NewTestimonialService(repo repository.TestimonialRepository) TestimonialService {
-    return &testimonialService{repo: repo}
+// NewTestimonialService builds the service. featured.Cache may be nil, in
+// which case the featured list is read from the database on every request.
+func NewTestimonialService(repo repository.TestimonialRepository, revisions repository.RevisionRepository, uow repository.UnitOfWork, store storage.Storage, screener *screening.Pipeline, maxImageBytes int64, featured FeaturedOptions) TestimonialService {
+    return &testimonialService{
+        repo:          repo,
+        revisions:     revisions,
+        uow:           uow,
+        storage:       store,
+        screener:      screener,
+        maxImageBytes: maxImageBytes,
+        featured:      featured,
+    }
 }
 
-func (s *testimonialService) CreateTestimonial(req *models.CreateTestimonialRequest) (*models.Testimonial, error) {
+// CreateTestimonial screens a public submission before storing it. Rejected
+// submissions return an unprocessable apperrors.Error; suspicious ones
+// are stored flagged for moderator review.
+func (s *testimonialService) CreateTestimonial(ctx context.Context, req *models.CreateTestimonialRequest) (*models.PublicTestimonial, error) {
+    if err := sanitizeCreateRequest(req); err != nil {
+        return nil, err
+    }
+    
+    result, err := s.screener.Screen(ctx, &screening.Submission{
+        FirstName:    req.FirstName,
+        LastName:     req.LastName,
+        Testimony:    req.Testimony,
+        ImageURL:     req.ImageURL,
+        Honeypot:     req.Website,
+        CaptchaToken: req.CaptchaToken,
+        ClientIP:     req.ClientIP,
+    })
+    if err != nil {
+        return nil, err
+    }
+    if err := result.Err(); err != nil {
+        slog.InfoContext(ctx, "submission rejected by screening", "reasons", result.Reasons)
+        return nil, apperrors.Unprocessable(apperrors.CodeSubmissionRejected, "Submission rejected: "+strings.Join(result.Reasons, "; ")).Wrap(err)
+    }
+    
     testimonial := &models.Testimonial{
         FirstName:   req.FirstName,
         LastName:    req.LastName,
         FullName:    fmt.Sprintf("%s %s", req.FirstName, req.LastName),
         ImageURL:    req.ImageURL, 
         Testimony:   req.Testimony,
+        Language:    req.Language,
         IsAnonymous: req.IsAnonymous,
         IsApproved:  false, 
+        ContentHash: screening.ContentHash(req.Testimony),
     }
     
-    if err := s.repo.Create(testimonial); err != nil {
+    if result.Verdict == screening.Flag {
+        reasons := strings.Join(result.Reasons, "; ")
+        testimonial.IsFlagged = true
+        testimonial.FlagReasons = &reasons
+        slog.InfoContext(ctx, "submission flagged for review", "reasons", result.Reasons)
+    }
+    
+    err = s.uow.Do(ctx, func(ctx context.Context) error {
+        if err := s.repo.Create(ctx, testimonial); err != nil {
+            return err
+        }
+        return s.recordRevision(ctx, &models.Testimonial{}, testimonial, models.RevisionCreate)
+    })
+    if err != nil {
         return nil, err
     }
     
+    public := toPublic(ctx, testimonial)
+    return &public, nil
+}
+
+func (s *testimonialService) GetAllTestimonials(ctx context.Context, filter models.TestimonialFilter) ([]models.PublicTestimonial, error) {
+    filter.ApprovedOnly = true
+    testimonials, err := s.repo.GetAll(ctx, filter)
+    if err != nil {
+        return nil, err
+    }
+    return toPublicList(ctx, testimonials), nil
+}
+
+func (s *testimonialService) GetTestimonialByID(ctx context.Context, id uuid.UUID) (*models.PublicTestimonial, error) {
+    testimonial, err := s.repo.GetByID(ctx, id)
+    if err != nil {
+        return nil, testimonialLookupError(err)
+    }
+    if !testimonial.IsApproved {
+        return nil, apperrors.NotFound(apperrors.CodeTestimonialNotFound, "Testimonial not found")
+    }
+    public := toPublic(ctx, testimonial)
+    return &public, nil
+}
+
+func (s *testimonialService) GetPaginatedTestimonials(ctx context.Context, page, limit int, filter models.TestimonialFilter) ([]models.PublicTestimonial, int64, error) {
+    filter.ApprovedOnly = true
+    testimonials, total, err := s.getPaginated(ctx, page, limit, filter)
+    if err != nil {
+        return nil, 0, err
+    }
+    return toPublicList(ctx, testimonials), total, nil
+}
+
+// UploadTestimonialImage replaces the photo of a testimonial that has not
+// been approved yet. Once it is public only moderators may change it.
+func (s *testimonialService) UploadTestimonialImage(ctx context.Context, id uuid.UUID, version int64, data []byte) (*models.PublicTestimonial, error) {
+    testimonial, err := s.uploadImage(ctx, id, version, data, true)
+    if err != nil {
+        return nil, err
+    }
+    public := toPublic(ctx, testimonial)
+    return &public, nil
+}
+
+func (s *testimonialService) AdminUploadTestimonialImage(ctx context.Context, id uuid.UUID, version int64, data []byte) (*models.AdminTestimonial, error) {
+    testimonial, err := s.uploadImage(ctx, id, version, data, false)
+    if err != nil {
+        return nil, err
+    }
+    admin := testimonial.ToAdmin()
+    return &admin, nil
+}
+
+// uploadImage validates and resizes an uploaded photo, stores each variant
+// and records the display and thumbnail URLs on the testimonial. The photo it
+// replaces is deleted once the new one is saved; if saving fails, the new
+// files are deleted instead.
+func (s *testimonialService) uploadImage(ctx context.Context, id uuid.UUID, version int64, data []byte, pendingOnly bool) (*models.Testimonial, error) {
+    testimonial, err := s.repo.GetByID(ctx, id)
+    if err != nil {
+        return nil, testimonialLookupError(err)
+    }
+    if err := checkImageUpload(testimonial, version, pendingOnly); err != nil {
+        return nil, err
+    }
+    
+    variants, err := media.ProcessImage(data, s.maxImageBytes, media.StandardImageVariants)
+    if err != nil {
+        return nil, uploadError(err)
+    }
+    
+    // A fresh suffix per upload so replaced photos are never served from a stale cache
+    suffix := uuid.New().String()
+    
+    urls := make(map[string]string, len(variants))
+    stored := make([]string, 0, len(variants))
+    for _, variant := range variants {
+        key := fmt.Sprintf("testimonials/%s/%s-%s%s", testimonial.ID, variant.Variant, suffix, variant.Extension)
+        url, err := s.storage.Put(ctx, key, bytes.NewReader(variant.Data), int64(len(variant.Data)), variant.ContentType)
+        if err != nil {
+            s.deleteImages(ctx, id, stored...)
+            return nil, fmt.Errorf("failed to store %s image: %w", variant.Variant, err)
+        }
+        urls[variant.Variant] = url
+        stored = append(stored, url)
+    }
+    
+    var replaced []string
+    err = s.uow.Do(ctx, func(ctx context.Context) error {
+        var err error
+        testimonial, err = s.repo.GetByIDForUpdate(ctx, id)
+        if err != nil {
+            return testimonialLookupError(err)
+        }
+        if err := checkImageUpload(testimonial, version, pendingOnly); err != nil {
+            return err
+        }
+        
+        before := *testimonial
+        replaced = nil
+        if url, ok := urls["thumb"]; ok {
+            if testimonial.ThumbnailURL != nil {
+                replaced = append(replaced, *testimonial.ThumbnailURL)
+            }
+            testimonial.ThumbnailURL = &url
+        }
+        if url, ok := urls["display"]; ok {
+            if testimonial.ImageURL != nil {
+                replaced = append(replaced, *testimonial.ImageURL)
+            }
+            testimonial.ImageURL = &url
+        }
+        return s.save(ctx, &before, testimonial, models.RevisionImage)
+    })
+    if err != nil {
+        s.deleteImages(ctx, id, stored...)
+        return nil, err
+    }
+    
+    s.deleteImages(ctx, id, replaced...)
+    s.invalidateFeatured(ctx)
     return testimonial, nil
 }
 
-func (s *testimonialService) GetAllTestimonials(approved bool) ([]models.Testimonial, error) {
-    return s.repo.GetAll(approved)
+// checkImageUpload applies the version check and, for submitters, refuses
+// testimonials that moderators have already approved.
+func checkImageUpload(testimonial *models.Testimonial, version int64, pendingOnly bool) error {
+    if err := checkVersion(testimonial, version); err != nil {
+        return err
+    }
+    if pendingOnly && testimonial.IsApproved {
+        return apperrors.Conflict(apperrors.CodeAlreadyApproved, "The photo of an approved testimonial can only be changed by a moderator")
+    }
+    return nil
+}
+
+// deleteImages removes uploaded photos of the testimonial from storage. URLs
+// that do not point at one of its uploads, such as links set by hand, are
+// left alone, and failures are only logged since the files are unreferenced.
+func (s *testimonialService) deleteImages(ctx context.Context, id uuid.UUID, urls ...string) {
+    for _, imageURL := range urls {
+        key, ok := imageKey(id, imageURL)
+        if !ok {
+            continue
+        }
+        if err := s.storage.Delete(ctx, key); err != nil {
+            slog.WarnContext(ctx, "failed to delete testimonial image", "key", key, "error", err)
+        }
+    }
+}
+
+// imageKey recovers the storage key of a photo uploaded for the testimonial
+// from its public URL, which ends in the key.
+func imageKey(id uuid.UUID, imageURL string) (string, bool) {
+    parsed, err := url.Parse(imageURL)
+    if err != nil {
+        return "", false
+    }
+    prefix := fmt.Sprintf("testimonials/%s/", id)
+    i := strings.LastIndex(parsed.Path, "/"+prefix)
+    if i < 0 {
+        return "", false
+    }
+    key := parsed.Path[i+1:]
+    // Recordings live in subdirectories; photos sit directly under the prefix
+    name := strings.TrimPrefix(key, prefix)
+    if name == "" || strings.Contains(name, "/") {
+        return "", false
+    }
+    return key, true
+}
+
+func (s *testimonialService) AdminGetAllTestimonials(ctx context.Context, filter models.TestimonialFilter) ([]models.AdminTestimonial, error) {
+    testimonials, err := s.repo.GetAll(ctx, filter)
+    if err != nil {
+        return nil, err
+    }
+    return toAdminList(testimonials), nil
 }
 
-func (s *testimonialService) GetTestimonialByID(id uuid.UUID) (*models.Testimonial, error) {
-    return s.repo.GetByID(id)
+func (s *testimonialService) AdminGetTestimonialByID(ctx context.Context, id uuid.UUID) (*models.AdminTestimonial, error) {
+    testimonial, err := s.repo.GetByID(ctx, id)
+    if err != nil {
+        return nil, testimonialLookupError(err)
+    }
+    admin := testimonial.ToAdmin()
+    return &admin, nil
+}
+
+func (s *testimonialService) AdminGetPaginatedTestimonials(ctx context.Context, page, limit int, filter models.TestimonialFilter) ([]models.AdminTestimonial, int64, error) {
+    testimonials, total, err := s.getPaginated(ctx, page, limit, filter)
+    if err != nil {
+        return nil, 0, err
+    }
+    return toAdminList(testimonials), total, nil
 }
 
-func (s *testimonialService) UpdateTestimonial(id uuid.UUID, req *models.UpdateTestimonialRequest) (*models.Testimonial, error) {
-    testimonial, err := s.repo.GetByID(id)
+func (s *testimonialService) UpdateTestimonial(ctx context.Context, id uuid.UUID, version int64, req *models.UpdateTestimonialRequest) (*models.AdminTestimonial, error) {
+    if err := sanitizeUpdateRequest(req); err != nil {
+        return nil, err
+    }
+    
+    var testimonial *models.Testimonial
+    err := s.uow.Do(ctx, func(ctx context.Context) error {
+        var err error
+        testimonial, err = s.repo.GetByIDForUpdate(ctx, id)
+        if err != nil {
+            return testimonialLookupError(err)
+        }
+        if err := checkVersion(testimonial, version); err != nil {
+            return err
+        }
+        if req.Language != nil && testimonial.Translation(*req.Language) != nil {
+            return translatedLanguageError()
+        }
+        
+        before := *testimonial
+        applyUpdate(testimonial, req)
+        return s.save(ctx, &before, testimonial, models.RevisionUpdate)
+    })
     if err != nil {
         return nil, err
     }
     
-    // Update fields if provided
+    s.invalidateFeatured(ctx)
+    admin := testimonial.ToAdmin()
+    return &admin, nil
+}
+
+// applyUpdate copies the fields present in req onto testimonial.
+func applyUpdate(testimonial *models.Testimonial, req *models.UpdateTestimonialRequest) {
     if req.FirstName != nil {
         testimonial.FirstName = *req.FirstName
     }
@@ -73,6 +381,10 @@ func (s *testimonialService) UpdateTestimonial(id uuid.UUID, req *models.UpdateT
     }
     if req.Testimony != nil {
         testimonial.Testimony = *req.Testimony
+        testimonial.ContentHash = screening.ContentHash(*req.Testimony)
+    }
+    if req.Language != nil {
+        testimonial.Language = *req.Language
     }
     if req.IsAnonymous != nil {
         testimonial.IsAnonymous = *req.IsAnonymous
@@ -80,40 +392,175 @@ func (s *testimonialService) UpdateTestimonial(id uuid.UUID, req *models.UpdateT
     if req.IsApproved != nil {
         testimonial.IsApproved = *req.IsApproved
     }
+    if req.IsFlagged != nil {
+        testimonial.IsFlagged = *req.IsFlagged
+    }
+}
+
+func (s *testimonialService) DeleteTestimonial(ctx context.Context, id uuid.UUID, version int64) error {
+    err := s.uow.Do(ctx, func(ctx context.Context) error {
+        testimonial, err := s.repo.GetByIDForUpdate(ctx, id)
+        if err != nil {
+            return testimonialLookupError(err)
+        }
+        if err := checkVersion(testimonial, version); err != nil {
+            return err
+        }
+        return s.repo.Delete(ctx, id)
+    })
+    if err != nil {
+        return err
+    }
     
-    if err := s.repo.Update(testimonial); err != nil {
+    s.invalidateFeatured(ctx)
+    return nil
+}
+
+func (s *testimonialService) ApproveTestimonial(ctx context.Context, id uuid.UUID, version int64) (*models.AdminTestimonial, error) {
+    var testimonial *models.Testimonial
+    err := s.uow.Do(ctx, func(ctx context.Context) error {
+        var err error
+        testimonial, err = s.repo.GetByIDForUpdate(ctx, id)
+        if err != nil {
+            return testimonialLookupError(err)
+        }
+        if err := checkVersion(testimonial, version); err != nil {
+            return err
+        }
+        
+        before := *testimonial
+        testimonial.IsApproved = true
+        testimonial.IsFlagged = false // Approval is the moderator's review of any flags
+        testimonial.RejectedAt = nil
+        return s.save(ctx, &before, testimonial, models.RevisionApprove)
+    })
+    if err != nil {
         return nil, err
     }
     
-    return testimonial, nil
+    s.invalidateFeatured(ctx)
+    admin := testimonial.ToAdmin()
+    return &admin, nil
 }
 
-func (s *testimonialService) DeleteTestimonial(id uuid.UUID) error {
-    return s.repo.Delete(id)
+func (s *testimonialService) AdminGetDeletedTestimonials(ctx context.Context, page, limit int) ([]models.AdminTestimonial, int64, error) {
+    page, limit = normalizePage(page, limit)
+    testimonials, total, err := s.repo.GetDeletedPaginated(ctx, page, limit)
+    if err != nil {
+        return nil, 0, err
+    }
+    return toAdminList(testimonials), total, nil
 }
 
-func (s *testimonialService) GetPaginatedTestimonials(page, limit int, approved bool) ([]models.Testimonial, int64, error) {
+func (s *testimonialService) RestoreTestimonial(ctx context.Context, id uuid.UUID) (*models.AdminTestimonial, error) {
+    if err := s.repo.Restore(ctx, id); err != nil {
+        return nil, trashLookupError(err)
+    }
+    s.invalidateFeatured(ctx)
+    
+    testimonial, err := s.repo.GetByID(ctx, id)
+    if err != nil {
+        return nil, testimonialLookupError(err)
+    }
+    admin := testimonial.ToAdmin()
+    return &admin, nil
+}
+
+// PurgeTestimonial permanently deletes a testimonial from the trash. Uploaded
+// files are left in storage.
+func (s *testimonialService) PurgeTestimonial(ctx context.Context, id uuid.UUID) error {
+    return trashLookupError(s.repo.Purge(ctx, id))
+}
+
+func (s *testimonialService) getPaginated(ctx context.Context, page, limit int, filter models.TestimonialFilter) ([]models.Testimonial, int64, error) {
+    page, limit = normalizePage(page, limit)
+    return s.repo.GetPaginated(ctx, page, limit, filter)
+}
+
+// normalizePage falls back to the first page of 10 for out-of-range input.
+func normalizePage(page, limit int) (int, int) {
     if page < 1 {
         page = 1
     }
     if limit < 1 || limit > 100 {
         limit = 10
     }
+    return page, limit
+}
+
+// sanitizeCreateRequest strips markup from names and reduces the testimony to
+// safe formatting tags before anything is screened or stored.
+func sanitizeCreateRequest(req *models.CreateTestimonialRequest) error {
+    req.FirstName = validation.SanitizeText(req.FirstName)
+    req.LastName = validation.SanitizeText(req.LastName)
+    req.Testimony = validation.SanitizeHTML(req.Testimony)
+    req.Language = canonicalLanguage(req.Language)
     
-    return s.repo.GetPaginated(page, limit, approved)
+    switch {
+    case req.FirstName == "":
+        return emptyContentError("firstName")
+    case req.LastName == "":
+        return emptyContentError("lastName")
+    case validation.SanitizeText(req.Testimony) == "":
+        return emptyContentError("testimony")
+    }
+    return nil
 }
 
-func (s *testimonialService) ApproveTestimonial(id uuid.UUID) (*models.Testimonial, error) {
-    testimonial, err := s.repo.GetByID(id)
-    if err != nil {
-        return nil, err
+func sanitizeUpdateRequest(req *models.UpdateTestimonialRequest) error {
+    names := map[string]*string{"firstName": req.FirstName, "lastName": req.LastName}
+    for field, name := range names {
+        if name == nil {
+            continue
+        }
+        if *name = validation.SanitizeText(*name); *name == "" {
+            return emptyContentError(field)
+        }
     }
-    
-    testimonial.IsApproved = true
-    
-    if err := s.repo.Update(testimonial); err != nil {
-        return nil, err
+    if req.Testimony != nil {
+        *req.Testimony = validation.SanitizeHTML(*req.Testimony)
+        if validation.SanitizeText(*req.Testimony) == "" {
+            return emptyContentError("testimony")
+        }
     }
-    
-    return testimonial, nil
+    if req.Language != nil {
+        *req.Language = canonicalLanguage(*req.Language)
+    }
+    return nil
+}
+
+// canonicalLanguage normalizes a language tag the binding already validated,
+// defaulting to i18n.DefaultLanguage when none was given.
+func canonicalLanguage(tag string) string {
+    if tag == "" {
+        return i18n.DefaultLanguage
+    }
+    if canonical, err := i18n.Canonical(tag); err == nil {
+        return canonical
+    }
+    return tag
+}
+
+// toPublic converts a testimonial for the public, in the language that best
+// serves the caller's Accept-Language.
+func toPublic(ctx context.Context, testimonial *models.Testimonial) models.PublicTestimonial {
+    public := testimonial.ToPublic()
+    public.Localize(testimonial.Translations, i18n.Preferences(ctx))
+    return public
+}
+
+func toPublicList(ctx context.Context, testimonials []models.Testimonial) []models.PublicTestimonial {
+    public := make([]models.PublicTestimonial, 0, len(testimonials))
+    for i := range testimonials {
+        public = append(public, toPublic(ctx, &testimonials[i]))
+    }
+    return public
+}
+
+func toAdminList(testimonials []models.Testimonial) []models.AdminTestimonial {
+    admin := make([]models.AdminTestimonial, 0, len(testimonials))
+    for i := range testimonials {
+        admin = append(admin, testimonials[i].ToAdmin())
+    }
+    return admin
 }
\ No newline at end of file
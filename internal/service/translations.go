@@ -0,0 +1,106 @@
+package service
+
+import (
+    "context"
+    "sort"
+
+    "github.com/google/uuid"
+    "wisdomHouse-backend/internal/models"
+    "wisdomHouse-backend/internal/validation"
+)
+
+// SaveTranslation adds or replaces the testimony's translation into language,
+// which must already be canonical. The change bumps the testimonial's version
+// and is recorded as a revision, so cached copies and ETags are refreshed.
+func (s *testimonialService) SaveTranslation(ctx context.Context, id uuid.UUID, version int64, language string, req *models.TranslationRequest) (*models.AdminTestimonial, error) {
+    req.Testimony = validation.SanitizeHTML(req.Testimony)
+    if validation.SanitizeText(req.Testimony) == "" {
+        return nil, emptyContentError("testimony")
+    }
+    
+    var testimonial *models.Testimonial
+    err := s.uow.Do(ctx, func(ctx context.Context) error {
+        var err error
+        testimonial, err = s.repo.GetByIDForUpdate(ctx, id)
+        if err != nil {
+            return testimonialLookupError(err)
+        }
+        if err := checkVersion(testimonial, version); err != nil {
+            return err
+        }
+        if language == testimonial.Language {
+            return translatedLanguageError()
+        }
+        
+        translation := models.TestimonialTranslation{
+            TestimonialID: id,
+            Language:      language,
+            Testimony:     req.Testimony,
+        }
+        if existing := testimonial.Translation(language); existing != nil {
+            translation.CreatedAt = existing.CreatedAt
+        }
+        if err := s.repo.SaveTranslation(ctx, &translation); err != nil {
+            return err
+        }
+        
+        before := *testimonial
+        testimonial.Translations = replaceTranslation(testimonial.Translations, language, &translation)
+        return s.save(ctx, &before, testimonial, models.RevisionTranslate)
+    })
+    if err != nil {
+        return nil, err
+    }
+    
+    s.invalidateFeatured(ctx)
+    admin := testimonial.ToAdmin()
+    return &admin, nil
+}
+
+// DeleteTranslation removes the testimony's translation into language.
+func (s *testimonialService) DeleteTranslation(ctx context.Context, id uuid.UUID, version int64, language string) (*models.AdminTestimonial, error) {
+    var testimonial *models.Testimonial
+    err := s.uow.Do(ctx, func(ctx context.Context) error {
+        var err error
+        testimonial, err = s.repo.GetByIDForUpdate(ctx, id)
+        if err != nil {
+            return testimonialLookupError(err)
+        }
+        if err := checkVersion(testimonial, version); err != nil {
+            return err
+        }
+        if err := s.repo.DeleteTranslation(ctx, id, language); err != nil {
+            return translationLookupError(err)
+        }
+        
+        before := *testimonial
+        testimonial.Translations = replaceTranslation(testimonial.Translations, language, nil)
+        return s.save(ctx, &before, testimonial, models.RevisionTranslate)
+    })
+    if err != nil {
+        return nil, err
+    }
+    
+    s.invalidateFeatured(ctx)
+    admin := testimonial.ToAdmin()
+    return &admin, nil
+}
+
+// replaceTranslation returns a copy of translations with the one in language
+// replaced by translation, or removed when translation is nil. The copy keeps
+// the revision's before snapshot intact.
+func replaceTranslation(translations []models.TestimonialTranslation, language string, translation *models.TestimonialTranslation) []models.TestimonialTranslation {
+    updated := make([]models.TestimonialTranslation, 0, len(translations)+1)
+    for _, existing := range translations {
+        if existing.Language != language {
+            updated = append(updated, existing)
+        }
+    }
+    if translation != nil {
+        updated = append(updated, *translation)
+    }
+    sort.Slice(updated, func(i, j int) bool {
+        return updated[i].Language < updated[j].Language
+    })
+    return updated
+}
@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"wisdomHouse-backend/internal/config"
+)
+
+// ErrInvalidLink is returned by OpenSigned for links that were not signed by
+// this storage or have expired.
+var ErrInvalidLink = errors.New("download link is invalid or has expired")
+
+// LocalStorage writes files beneath a directory on the local filesystem.
+// The directory is expected to be served by the HTTP router at baseURL.
+// Private files go to a separate directory that is never served directly;
+// they are downloaded from downloadBaseURL with links signed by signingKey,
+// which the router checks with OpenSigned.
+type LocalStorage struct {
+	dir             string
+	baseURL         string
+	privateDir      string
+	downloadBaseURL string
+	signingKey      []byte
+}
+
+// NewLocalStorage creates the upload and private directories if needed and
+// returns a LocalStorage rooted there
+func NewLocalStorage(cfg *config.StorageConfig) (*LocalStorage, error) {
+	if err := os.MkdirAll(cfg.LocalDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
+	}
+	if err := os.MkdirAll(cfg.PrivateDir, 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create private directory: %w", err)
+	}
+	return &LocalStorage{
+		dir:             cfg.LocalDir,
+		baseURL:         strings.TrimRight(cfg.PublicBaseURL, "/"),
+		privateDir:      cfg.PrivateDir,
+		downloadBaseURL: strings.TrimRight(cfg.DownloadBaseURL, "/"),
+		signingKey:      []byte(cfg.SigningSecret),
+	}, nil
+}
+
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	path, err := resolve(s.dir, key)
+	if err != nil {
+		return "", err
+	}
+	if err := writeFile(path, r, 0o644); err != nil {
+		return "", err
+	}
+	return s.baseURL + "/" + key, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := resolve(s.dir, key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to delete file: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStorage) PutPrivate(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	path, err := resolve(s.privateDir, key)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, r, 0o600)
+}
+
+// SignedURL links to the private file at key under downloadBaseURL, with the
+// expiry and an HMAC of both in the query string.
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if _, err := resolve(s.privateDir, key); err != nil {
+		return "", err
+	}
+	expires := strconv.FormatInt(time.Now().Add(ttl).Unix(), 10)
+	query := url.Values{
+		"expires":   {expires},
+		"signature": {s.sign(key, expires)},
+	}
+	return s.downloadBaseURL + "/" + escapeKey(key) + "?" + query.Encode(), nil
+}
+
+// OpenSigned opens the private file a signed link points to. It returns
+// ErrInvalidLink when the signature does not match or the link has expired,
+// and an error satisfying errors.Is(err, fs.ErrNotExist) when the file is
+// gone.
+func (s *LocalStorage) OpenSigned(key, expires, signature string) (*os.File, error) {
+	if !hmac.Equal([]byte(signature), []byte(s.sign(key, expires))) {
+		return nil, ErrInvalidLink
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || time.Now().Unix() > expiresAt {
+		return nil, ErrInvalidLink
+	}
+
+	path, err := resolve(s.privateDir, key)
+	if err != nil {
+		return nil, ErrInvalidLink
+	}
+	return os.Open(path)
+}
+
+func (s *LocalStorage) DeletePrivateBefore(ctx context.Context, prefix string, cutoff time.Time) (int, error) {
+	root, err := resolve(s.privateDir, prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted := 0
+	err = filepath.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil || !info.ModTime().Before(cutoff) {
+			return err
+		}
+		if err := os.Remove(path); err != nil {
+			return fmt.Errorf("failed to delete file: %w", err)
+		}
+		deleted++
+		// Drop the directory too once it is empty
+		if dir := filepath.Dir(path); dir != root {
+			os.Remove(dir)
+		}
+		return nil
+	})
+	return deleted, err
+}
+
+func (s *LocalStorage) sign(key, expires string) string {
+	mac := hmac.New(sha256.New, s.signingKey)
+	mac.Write([]byte(key + "\n" + expires))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// resolve resolves key inside dir, rejecting keys that would escape it.
+func resolve(dir, key string) (string, error) {
+	if !filepath.IsLocal(filepath.FromSlash(key)) {
+		return "", fmt.Errorf("invalid storage key %q", key)
+	}
+	return filepath.Join(dir, filepath.FromSlash(key)), nil
+}
+
+// writeFile copies r to path, creating its directory, and removes the
+// partial file if the copy fails.
+func writeFile(path string, r io.Reader, perm os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, perm)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	if _, err := io.Copy(file, r); err != nil {
+		file.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write file: %w", err)
+	}
+	return nil
+}
+
+// escapeKey path-escapes each segment of key.
+func escapeKey(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,318 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"wisdomHouse-backend/internal/config"
+)
+
+// privatePrefix keeps private objects apart from public ones when both share
+// a bucket, so a public-read policy can be scoped to exclude it.
+const privatePrefix = "private/"
+
+// maxPresignTTL is the longest lifetime S3 accepts for a presigned URL.
+const maxPresignTTL = 7 * 24 * time.Hour
+
+// S3Storage writes objects to an S3-compatible bucket (AWS S3, MinIO, R2, ...)
+// using plain HTTP requests signed with AWS Signature Version 4. Private
+// objects go under privatePrefix in privateBucket and are shared through
+// presigned URLs.
+type S3Storage struct {
+	endpoint      *url.URL
+	region        string
+	bucket        string
+	privateBucket string
+	accessKey     string
+	secretKey     string
+	pathStyle     bool
+	publicBaseURL string
+	client        *http.Client
+}
+
+func NewS3Storage(cfg *config.StorageConfig) (*S3Storage, error) {
+	if cfg.S3Bucket == "" || cfg.S3AccessKey == "" || cfg.S3SecretKey == "" {
+		return nil, fmt.Errorf("s3 storage requires a bucket, access key and secret key")
+	}
+
+	endpoint, err := url.Parse(cfg.S3Endpoint)
+	if err != nil || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", cfg.S3Endpoint)
+	}
+
+	privateBucket := cfg.S3PrivateBucket
+	if privateBucket == "" {
+		privateBucket = cfg.S3Bucket
+	}
+
+	return &S3Storage{
+		endpoint:      endpoint,
+		region:        cfg.S3Region,
+		bucket:        cfg.S3Bucket,
+		privateBucket: privateBucket,
+		accessKey:     cfg.S3AccessKey,
+		secretKey:     cfg.S3SecretKey,
+		pathStyle:     cfg.S3PathStyle,
+		publicBaseURL: strings.TrimRight(cfg.PublicBaseURL, "/"),
+		client:        &http.Client{Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error) {
+	objectURL := s.objectURL(s.bucket, key)
+	if err := s.put(ctx, objectURL, r, size, contentType); err != nil {
+		return "", err
+	}
+
+	if s.publicBaseURL != "" {
+		return s.publicBaseURL + "/" + key, nil
+	}
+	return objectURL, nil
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	return s.delete(ctx, s.objectURL(s.bucket, key))
+}
+
+func (s *S3Storage) PutPrivate(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	return s.put(ctx, s.objectURL(s.privateBucket, privatePrefix+key), r, size, contentType)
+}
+
+// SignedURL presigns a GET of the private object at key (AWS Signature
+// Version 4 query authentication), valid for ttl up to seven days.
+func (s *S3Storage) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if ttl <= 0 || ttl > maxPresignTTL {
+		return "", fmt.Errorf("presigned URL lifetime %s is outside (0, %s]", ttl, maxPresignTTL)
+	}
+	return s.presign(s.objectURL(s.privateBucket, privatePrefix+key), ttl, time.Now().UTC())
+}
+
+// presign returns rawURL with Signature Version 4 query authentication for a
+// GET made at now.
+func (s *S3Storage) presign(rawURL string, ttl time.Duration, now time.Time) (string, error) {
+	objectURL, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", now.Format("20060102"), s.region)
+	query := url.Values{
+		"X-Amz-Algorithm":     {"AWS4-HMAC-SHA256"},
+		"X-Amz-Credential":    {s.accessKey + "/" + scope},
+		"X-Amz-Date":          {now.Format("20060102T150405Z")},
+		"X-Amz-Expires":       {strconv.Itoa(int(ttl.Seconds()))},
+		"X-Amz-SignedHeaders": {"host"},
+	}
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		objectURL.EscapedPath(),
+		canonicalQuery(query),
+		"host:" + objectURL.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+
+	query.Set("X-Amz-Signature", s.signature(now, canonicalRequest))
+	objectURL.RawQuery = canonicalQuery(query)
+	return objectURL.String(), nil
+}
+
+func (s *S3Storage) DeletePrivateBefore(ctx context.Context, prefix string, cutoff time.Time) (int, error) {
+	deleted := 0
+	continuation := ""
+	for {
+		page, err := s.listPrivate(ctx, privatePrefix+prefix, continuation)
+		if err != nil {
+			return deleted, err
+		}
+		for _, object := range page.Contents {
+			if !object.LastModified.Before(cutoff) {
+				continue
+			}
+			if err := s.delete(ctx, s.objectURL(s.privateBucket, object.Key)); err != nil {
+				return deleted, err
+			}
+			deleted++
+		}
+		if !page.IsTruncated {
+			return deleted, nil
+		}
+		continuation = page.NextContinuationToken
+	}
+}
+
+// listBucketResult is the part of a ListObjectsV2 response that
+// DeletePrivateBefore needs.
+type listBucketResult struct {
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+	Contents              []struct {
+		Key          string    `xml:"Key"`
+		LastModified time.Time `xml:"LastModified"`
+	} `xml:"Contents"`
+}
+
+// listPrivate fetches one page of the private bucket's objects under prefix.
+func (s *S3Storage) listPrivate(ctx context.Context, prefix, continuation string) (*listBucketResult, error) {
+	query := url.Values{"list-type": {"2"}, "prefix": {prefix}}
+	if continuation != "" {
+		query.Set("continuation-token", continuation)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(s.privateBucket, ""), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.URL.RawQuery = canonicalQuery(query)
+	s.sign(req, sha256Hex(nil))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("s3 request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("s3 %s %s returned %d: %s", req.Method, req.URL.Path, resp.StatusCode, body)
+	}
+
+	var result listBucketResult
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode s3 listing: %w", err)
+	}
+	return &result, nil
+}
+
+func (s *S3Storage) put(ctx context.Context, objectURL string, r io.Reader, size int64, contentType string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL, r)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	// Media uploads can be hundreds of megabytes, so the body is streamed
+	// rather than buffered for hashing.
+	s.sign(req, unsignedPayload)
+	return s.do(req)
+}
+
+func (s *S3Storage) delete(ctx context.Context, objectURL string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, objectURL, nil)
+	if err != nil {
+		return err
+	}
+	s.sign(req, sha256Hex(nil))
+	return s.do(req)
+}
+
+func (s *S3Storage) do(req *http.Request) error {
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("s3 request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("s3 %s %s returned %d: %s", req.Method, req.URL.Path, resp.StatusCode, body)
+	}
+	return nil
+}
+
+// objectURL builds the object address using path-style (endpoint/bucket/key,
+// needed by MinIO) or virtual-hosted style (bucket.endpoint/key) addressing.
+func (s *S3Storage) objectURL(bucket, key string) string {
+	escapedKey := escapeKey(key)
+
+	if s.pathStyle {
+		return fmt.Sprintf("%s://%s/%s/%s", s.endpoint.Scheme, s.endpoint.Host, bucket, escapedKey)
+	}
+	return fmt.Sprintf("%s://%s.%s/%s", s.endpoint.Scheme, bucket, s.endpoint.Host, escapedKey)
+}
+
+// canonicalQuery encodes query the way Signature Version 4 expects: sorted
+// by key, with spaces as %20 rather than +.
+func canonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+// unsignedPayload tells S3 the request body is not covered by the signature.
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// sign adds AWS Signature Version 4 headers to req.
+func (s *S3Storage) sign(req *http.Request, payloadHash string) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+
+	req.Header.Set("Host", req.URL.Host)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headerNames := []string{"host", "x-amz-content-sha256", "x-amz-date"}
+	if req.Header.Get("Content-Type") != "" {
+		headerNames = append([]string{"content-type"}, headerNames...)
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range headerNames {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+	signedHeaders := strings.Join(headerNames, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := fmt.Sprintf("%s/%s/s3/aws4_request", date, s.region)
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, s.signature(now, canonicalRequest),
+	))
+}
+
+// signature signs a Signature Version 4 canonical request made at now.
+func (s *S3Storage) signature(now time.Time, canonicalRequest string) string {
+	date := now.Format("20060102")
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		now.Format("20060102T150405Z"),
+		fmt.Sprintf("%s/%s/s3/aws4_request", date, s.region),
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"wisdomHouse-backend/internal/config"
+)
+
+// Storage persists uploaded files and returns the URL they are served from.
+// Private files live apart from public ones and are only reachable through
+// short-lived signed links.
+type Storage interface {
+	// Put writes size bytes read from r under key and returns the public URL.
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) (string, error)
+	Delete(ctx context.Context, key string) error
+
+	// PutPrivate writes size bytes read from r under key where no public URL
+	// reaches them.
+	PutPrivate(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// SignedURL returns a link that downloads the private file at key until
+	// ttl has passed.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+	// DeletePrivateBefore deletes the private files under prefix last written
+	// before cutoff and reports how many were deleted.
+	DeletePrivateBefore(ctx context.Context, prefix string, cutoff time.Time) (int, error)
+}
+
+// New builds the storage backend selected by cfg.Driver.
+func New(cfg *config.StorageConfig) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		return NewLocalStorage(cfg)
+	case "s3":
+		return NewS3Storage(cfg)
+	default:
+		return nil, fmt.Errorf("unknown storage driver %q", cfg.Driver)
+	}
+}
@@ -0,0 +1,145 @@
+package validation
+
+import (
+	"html"
+	"io"
+	"strings"
+
+	xhtml "golang.org/x/net/html"
+)
+
+// allowedTags are the formatting elements kept in rich-text testimonies.
+// Every attribute is dropped, so none of them can carry script or styling.
+var allowedTags = map[string]bool{
+	"p": true, "br": true, "strong": true, "b": true, "em": true, "i": true,
+	"u": true, "ul": true, "ol": true, "li": true, "blockquote": true,
+}
+
+// droppedContentTags have their content removed along with the tag itself.
+var droppedContentTags = map[string]bool{
+	"script": true, "style": true, "iframe": true, "object": true,
+	"embed": true, "noscript": true, "template": true, "svg": true, "math": true,
+}
+
+// SanitizeHTML reduces untrusted rich text to a small set of attribute-free
+// formatting tags. Disallowed tags are removed but their text is kept
+// (escaped); script-like elements are removed together with their content.
+func SanitizeHTML(input string) string {
+	tokenizer := xhtml.NewTokenizer(strings.NewReader(input))
+	var out strings.Builder
+	var open []string
+	skipDepth := 0
+
+	for {
+		switch tokenizer.Next() {
+		case xhtml.ErrorToken:
+			if tokenizer.Err() != io.EOF {
+				return html.EscapeString(input)
+			}
+			// Close anything the input left open so the output is well formed
+			for i := len(open) - 1; i >= 0; i-- {
+				out.WriteString("</" + open[i] + ">")
+			}
+			return strings.TrimSpace(out.String())
+
+		case xhtml.TextToken:
+			if skipDepth == 0 {
+				out.WriteString(html.EscapeString(string(tokenizer.Text())))
+			}
+
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			if droppedContentTags[tag] {
+				skipDepth++
+				continue
+			}
+			if skipDepth > 0 || !allowedTags[tag] {
+				continue
+			}
+			if tag == "br" {
+				out.WriteString("<br>")
+				continue
+			}
+			out.WriteString("<" + tag + ">")
+			open = append(open, tag)
+
+		case xhtml.EndTagToken:
+			name, _ := tokenizer.TagName()
+			tag := string(name)
+			if droppedContentTags[tag] {
+				if skipDepth > 0 {
+					skipDepth--
+				}
+				continue
+			}
+			if skipDepth > 0 || !allowedTags[tag] {
+				continue
+			}
+			// Only close tags that are open, unwinding any left unclosed inside
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] == tag {
+					for j := len(open) - 1; j >= i; j-- {
+						out.WriteString("</" + open[j] + ">")
+					}
+					open = open[:i]
+					break
+				}
+			}
+		}
+	}
+}
+
+// SanitizeText strips all markup from a plain-text field such as a name and
+// collapses runs of whitespace.
+func SanitizeText(input string) string {
+	tokenizer := xhtml.NewTokenizer(strings.NewReader(input))
+	var out strings.Builder
+	for {
+		switch tokenizer.Next() {
+		case xhtml.ErrorToken:
+			return strings.Join(strings.Fields(out.String()), " ")
+		case xhtml.TextToken:
+			out.Write(tokenizer.Text())
+		}
+	}
+}
+
+// blockTags end a paragraph when converting rich text to plain text.
+var blockTags = map[string]bool{
+	"p": true, "br": true, "li": true, "ul": true, "ol": true, "blockquote": true,
+}
+
+// PlainParagraphs converts sanitized rich text into plain-text paragraphs,
+// for output formats that cannot render markup. List items are bulleted.
+func PlainParagraphs(input string) []string {
+	tokenizer := xhtml.NewTokenizer(strings.NewReader(input))
+	var paragraphs []string
+	var current strings.Builder
+	flush := func() {
+		if text := strings.Join(strings.Fields(current.String()), " "); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+		current.Reset()
+	}
+
+	for {
+		tokenType := tokenizer.Next()
+		switch tokenType {
+		case xhtml.ErrorToken:
+			flush()
+			return paragraphs
+		case xhtml.TextToken:
+			current.Write(tokenizer.Text())
+		case xhtml.StartTagToken, xhtml.SelfClosingTagToken, xhtml.EndTagToken:
+			name, _ := tokenizer.TagName()
+			if !blockTags[string(name)] {
+				continue
+			}
+			flush()
+			if string(name) == "li" && tokenType == xhtml.StartTagToken {
+				current.WriteString("• ")
+			}
+		}
+	}
+}
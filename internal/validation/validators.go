@@ -0,0 +1,74 @@
+package validation
+
+import (
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// personNamePattern allows letters from any script plus the separators found
+// in real names: spaces, hyphens, apostrophes and periods.
+var personNamePattern = regexp.MustCompile(`^[\p{L}\p{M}][\p{L}\p{M} '’.\-]*$`)
+
+// Register installs the custom validators on gin's binding engine and makes
+// validation errors report fields by their JSON names. Call once at startup.
+func Register() error {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return nil
+	}
+
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+		if name == "" {
+			return field.Name
+		}
+		return name
+	})
+
+	validators := map[string]validator.Func{
+		"notblank":   notBlank,
+		"personname": personName,
+		"httpurl":    httpURL,
+	}
+	for tag, fn := range validators {
+		if err := v.RegisterValidation(tag, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// notBlank fails strings made up entirely of whitespace, which "required"
+// lets through.
+func notBlank(fl validator.FieldLevel) bool {
+	return strings.TrimFunc(fl.Field().String(), unicode.IsSpace) != ""
+}
+
+func personName(fl validator.FieldLevel) bool {
+	return personNamePattern.MatchString(strings.TrimSpace(fl.Field().String()))
+}
+
+// httpURL accepts only absolute http(s) URLs, rejecting javascript:, data:
+// and other schemes that are dangerous when rendered as an image source.
+func httpURL(fl validator.FieldLevel) bool {
+	u, err := url.Parse(fl.Field().String())
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return u.Scheme == "http" || u.Scheme == "https"
+}
+
+// Validate checks a struct against its binding tags exactly as request
+// binding does, for input that arrives other than as a request body.
+func Validate(obj any) error {
+	return binding.Validator.ValidateStruct(obj)
+}
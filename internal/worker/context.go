@@ -0,0 +1,60 @@
+package worker
+
+import (
+    "context"
+
+    "go.opentelemetry.io/otel"
+    "go.opentelemetry.io/otel/propagation"
+
+    "wisdomHouse-backend/internal/logging"
+)
+
+// RequestScoped is implemented by tasks that remember the request which
+// enqueued them, so the pool can tag their logs with its request ID.
+type RequestScoped interface {
+    RequestID() string
+}
+
+// contextual is satisfied by tasks embedding RequestContext. The pool uses it
+// to hand the task a context carrying the span the task runs under.
+type contextual interface {
+    Context() context.Context
+    setContext(ctx context.Context)
+}
+
+// RequestContext records the originating request of a task: its correlation
+// ID and the trace context, serialized so it survives the queue. Embed it in
+// a task to satisfy RequestScoped.
+type RequestContext struct {
+    requestID string
+    carrier   propagation.MapCarrier
+    ctx       context.Context
+}
+
+func NewRequestContext(ctx context.Context) RequestContext {
+    carrier := propagation.MapCarrier{}
+    otel.GetTextMapPropagator().Inject(ctx, carrier)
+    return RequestContext{requestID: logging.RequestID(ctx), carrier: carrier}
+}
+
+func (r RequestContext) RequestID() string {
+    return r.requestID
+}
+
+// Context returns a background context carrying the originating request ID
+// and trace. Tasks outlive their request, so its cancellation must not reach
+// them. Once the pool has started the task's span, that span is the parent.
+func (r RequestContext) Context() context.Context {
+    if r.ctx != nil {
+        return r.ctx
+    }
+    ctx := logging.WithRequestID(context.Background(), r.requestID)
+    if len(r.carrier) > 0 {
+        ctx = otel.GetTextMapPropagator().Extract(ctx, r.carrier)
+    }
+    return ctx
+}
+
+func (r *RequestContext) setContext(ctx context.Context) {
+    r.ctx = ctx
+}
\ No newline at end of file
@@ -4,18 +4,39 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"log/slog"
 	"sync"
 	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"wisdomHouse-backend/internal/metrics"
+	"wisdomHouse-backend/internal/tracing"
 )
 
-// Task represents a job to be executed
+// Task represents a job to be executed. Name identifies this particular job
+// in logs; Type names the kind of job, from a small fixed set, for metrics.
 type Task interface {
     Execute() error
     Name() string
+    Type() string
     RetryCount() int
 }
 
+// Job is implemented by tasks that work on one identifiable record, such as
+// an export or a media file, so their traces can be found by its ID
+type Job interface {
+    ID() string
+}
+
+// FailureHandler is implemented by tasks that need to clean up or record the
+// failure once every retry has been exhausted
+type FailureHandler interface {
+    OnFailure(err error)
+}
+
 // Worker represents a single worker
 type Worker struct {
     id         int
@@ -64,12 +85,14 @@ func (wp *WorkerPool) Start() {
 // Submit adds a task to the queue
 func (wp *WorkerPool) Submit(task Task) {
     wp.taskQueue <- task
+    metrics.WorkerQueueDepth.Set(float64(len(wp.taskQueue)))
 }
 
 // SubmitWithTimeout adds a task with timeout
 func (wp *WorkerPool) SubmitWithTimeout(ctx context.Context, task Task, timeout time.Duration) error {
     select {
     case wp.taskQueue <- task:
+        metrics.WorkerQueueDepth.Set(float64(len(wp.taskQueue)))
         return nil
     case <-ctx.Done():
         return ctx.Err()
@@ -104,7 +127,13 @@ func (w *Worker) start() {
     
     for {
         select {
-        case task := <-w.taskQueue:
+        case task, ok := <-w.taskQueue:
+            if !ok {
+                // Queue closed during shutdown
+                w.isRunning.Store(false)
+                return
+            }
+            metrics.WorkerQueueDepth.Set(float64(len(w.taskQueue)))
             w.executeTask(task)
         case <-w.quit:
             w.isRunning.Store(false)
@@ -116,25 +145,50 @@ func (w *Worker) start() {
 
 func (w *Worker) executeTask(task Task) {
     start := time.Now()
-    log.Printf("Worker %d processing task: %s", w.id, task.Name())
+    ctx := context.Background()
+    if task, ok := task.(contextual); ok {
+        ctx = task.Context()
+    }
+    ctx, span := tracing.Tracer().Start(ctx, "worker."+task.Type(), trace.WithSpanKind(trace.SpanKindConsumer))
+    defer span.End()
+    if job, ok := task.(Job); ok {
+        span.SetAttributes(attribute.String("worker.job_id", job.ID()))
+    }
+    if task, ok := task.(contextual); ok {
+        task.setContext(ctx)
+    }
+    logger := slog.With("worker", w.id, "task", task.Name())
+    logger.DebugContext(ctx, "processing task")
+    
+    metrics.WorkerBusy.Inc()
+    defer metrics.WorkerBusy.Dec()
+    defer func() {
+        metrics.WorkerTaskDuration.WithLabelValues(task.Type()).Observe(time.Since(start).Seconds())
+    }()
     
     var err error
     maxRetries := task.RetryCount()
     
     for attempt := 0; attempt <= maxRetries; attempt++ {
         if attempt > 0 {
-            log.Printf("Worker %d retrying task %s (attempt %d/%d)", 
-                w.id, task.Name(), attempt, maxRetries)
+            metrics.WorkerTaskRetries.WithLabelValues(task.Type()).Inc()
+            logger.WarnContext(ctx, "retrying task", "attempt", attempt, "max_retries", maxRetries, "error", err)
             time.Sleep(time.Duration(attempt) * time.Second) // Exponential backoff
         }
         
         err = task.Execute()
         if err == nil {
-            log.Printf("Worker %d completed task: %s in %v", 
-                w.id, task.Name(), time.Since(start))
+            metrics.WorkerTasks.WithLabelValues(task.Type(), "succeeded").Inc()
+            logger.InfoContext(ctx, "task completed", "duration", time.Since(start))
             return
         }
     }
     
-    log.Printf("Worker %d task failed: %s, error: %v", w.id, task.Name(), err)
+    metrics.WorkerTasks.WithLabelValues(task.Type(), "failed").Inc()
+    logger.ErrorContext(ctx, "task failed", "duration", time.Since(start), "error", err)
+    tracing.RecordError(span, err)
+    
+    if handler, ok := task.(FailureHandler); ok {
+        handler.OnFailure(err)
+    }
 }
\ No newline at end of file
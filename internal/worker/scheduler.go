@@ -0,0 +1,33 @@
+package worker
+
+import (
+    "context"
+    "log/slog"
+    "time"
+)
+
+// scheduleSubmitTimeout bounds how long a scheduled run waits for queue room
+// before it is skipped until the next tick.
+const scheduleSubmitTimeout = 10 * time.Second
+
+// Every submits a task built by newTask once per interval until ctx is
+// cancelled. Cancel ctx before calling Shutdown so no submission races the
+// closing queue.
+func (wp *WorkerPool) Every(ctx context.Context, interval time.Duration, newTask func() Task) {
+    go func() {
+        ticker := time.NewTicker(interval)
+        defer ticker.Stop()
+        
+        for {
+            select {
+            case <-ticker.C:
+                task := newTask()
+                if err := wp.SubmitWithTimeout(ctx, task, scheduleSubmitTimeout); err != nil && ctx.Err() == nil {
+                    slog.Warn("skipped scheduled task", "task", task.Name(), "error", err)
+                }
+            case <-ctx.Done():
+                return
+            }
+        }
+    }()
+}
\ No newline at end of file
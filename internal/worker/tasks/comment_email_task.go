@@ -0,0 +1,51 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	"wisdomHouse-backend/internal/worker"
+)
+
+// NewCommentApprovedEmailTask tells a commenter their comment is now
+// visible under the testimony.
+func NewCommentApprovedEmailTask(ctx context.Context, sender Sender, to, authorName string) *EmailTask {
+	body := fmt.Sprintf(`
+    <!DOCTYPE html>
+    <html>
+    <body style="font-family: Arial, sans-serif; line-height: 1.6;">
+        <h2>Thank you, %s!</h2>
+        <p>Your comment has been approved and is now visible under the testimony.</p>
+        <p>Your encouragement means a great deal to the one who shared it.</p>
+        <br>
+        <p>Blessings,<br>The Wisdom House Team</p>
+    </body>
+    </html>`, html.EscapeString(authorName))
+
+	return newRequestEmailTask(ctx, sender, to, "Your comment has been approved", body)
+}
+
+// NewCommentReplyEmailTask tells a commenter that someone replied to them.
+func NewCommentReplyEmailTask(ctx context.Context, sender Sender, to, authorName, replierName, reply string) *EmailTask {
+	body := fmt.Sprintf(`
+    <!DOCTYPE html>
+    <html>
+    <body style="font-family: Arial, sans-serif; line-height: 1.6;">
+        <h2>Hello %s,</h2>
+        <p>%s replied to your comment:</p>
+        <blockquote style="border-left: 3px solid #ccc; margin: 0; padding-left: 12px;">%s</blockquote>
+        <br>
+        <p>Blessings,<br>The Wisdom House Team</p>
+    </body>
+    </html>`, html.EscapeString(authorName), html.EscapeString(replierName), html.EscapeString(reply))
+
+	return newRequestEmailTask(ctx, sender, to, "Someone replied to your comment", body)
+}
+
+// newRequestEmailTask is NewEmailTask tagged with the request that queued it.
+func newRequestEmailTask(ctx context.Context, sender Sender, to, subject, body string) *EmailTask {
+	task := NewEmailTask(sender, to, subject, body)
+	task.RequestContext = worker.NewRequestContext(ctx)
+	return task
+}
@@ -1,16 +1,19 @@
 package tasks
 
 import (
+	"context"
 	"fmt"
 	"time"
 	"wisdomHouse-backend/internal/email"
+	"wisdomHouse-backend/internal/worker"
 )
 
 type Sender interface {
-    SendHTML(to, subject, body string) error
+    SendHTML(ctx context.Context, to, subject, body string) error
 }
 
 type EmailTask struct {
+    worker.RequestContext
     To      string
     Subject string
     Body    string
@@ -29,13 +32,17 @@ func NewEmailTask(sender Sender, to, subject, body string) *EmailTask {
 }
 
 func (t *EmailTask) Execute() error {
-    return t.sender.SendHTML(t.To, t.Subject, t.Body)
+    return t.sender.SendHTML(t.Context(), t.To, t.Subject, t.Body)
 }
 
 func (t *EmailTask) Name() string {
     return fmt.Sprintf("email_task_%s_%d", t.To, time.Now().Unix())
 }
 
+func (t *EmailTask) Type() string {
+    return "email"
+}
+
 func (t *EmailTask) RetryCount() int {
     return t.Retries
 }
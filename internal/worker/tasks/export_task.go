@@ -0,0 +1,144 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"wisdomHouse-backend/internal/export"
+	"wisdomHouse-backend/internal/models"
+	"wisdomHouse-backend/internal/storage"
+	"wisdomHouse-backend/internal/worker"
+)
+
+// exportKeyPrefix is where exports are kept in private storage.
+const exportKeyPrefix = "exports/"
+
+// ExportTask builds an export file, stores it privately and emails the
+// requester a download link that expires after LinkTTL. Exports carry
+// submitters' real names, so they are never given a public URL.
+type ExportTask struct {
+	worker.RequestContext
+	JobID   uuid.UUID
+	Format  models.ExportFormat
+	Filter  models.TestimonialFilter
+	Email   string
+	Title   string
+	LinkTTL time.Duration
+	Retries int
+	Timeout time.Duration
+	source  export.Source
+	storage storage.Storage
+	sender  Sender
+	tempDir string
+}
+
+func NewExportTask(ctx context.Context, source export.Source, store storage.Storage, sender Sender, tempDir string, linkTTL time.Duration, jobID uuid.UUID, req *models.ExportRequest) *ExportTask {
+	return &ExportTask{
+		RequestContext: worker.NewRequestContext(ctx),
+		JobID:          jobID,
+		Format:         req.Format,
+		Filter:         req.Filter(),
+		Email:          req.Email,
+		Title:          req.Title,
+		LinkTTL:        linkTTL,
+		Retries:        1,
+		Timeout:        30 * time.Minute,
+		source:         source,
+		storage:        store,
+		sender:         sender,
+		tempDir:        tempDir,
+	}
+}
+
+func (t *ExportTask) Execute() error {
+	ctx, cancel := context.WithTimeout(t.Context(), t.Timeout)
+	defer cancel()
+
+	file, err := os.CreateTemp(t.tempDir, "export-*."+string(t.Format))
+	if err != nil {
+		return fmt.Errorf("failed to create export file: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	now := time.Now()
+	count, err := export.Write(ctx, t.source, t.Format, t.Filter, file, export.Options{Title: t.Title, GeneratedAt: now})
+	if err != nil {
+		return fmt.Errorf("failed to write export: %w", err)
+	}
+
+	size, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	key := fmt.Sprintf("%s%s/%s", exportKeyPrefix, t.JobID, t.Format.FileName(now))
+	if err := t.storage.PutPrivate(ctx, key, file, size, t.Format.ContentType()); err != nil {
+		return fmt.Errorf("failed to store export: %w", err)
+	}
+	url, err := t.storage.SignedURL(ctx, key, t.LinkTTL)
+	if err != nil {
+		return fmt.Errorf("failed to sign export link: %w", err)
+	}
+
+	slog.InfoContext(ctx, "export ready", "job_id", t.JobID, "format", t.Format, "testimonials", count)
+	body := fmt.Sprintf(`
+    <!DOCTYPE html>
+    <html>
+    <body style="font-family: Arial, sans-serif; line-height: 1.6;">
+        <h2>Your export is ready</h2>
+        <p>%d testimonies were exported as %s.</p>
+        <p><a href="%s">Download the export</a></p>
+        <p>The link works until %s. Anyone with it can download the file, so please do not forward it.</p>
+        <br>
+        <p>Blessings,<br>The Wisdom House Team</p>
+    </body>
+    </html>`, count, html.EscapeString(string(t.Format)), html.EscapeString(url), now.Add(t.LinkTTL).UTC().Format("Jan 2, 2006 15:04 MST"))
+
+	return t.sender.SendHTML(ctx, t.Email, "Your testimonial export is ready", body)
+}
+
+// OnFailure tells the requester the export could not be produced.
+func (t *ExportTask) OnFailure(err error) {
+	ctx := t.Context()
+	slog.ErrorContext(ctx, "export failed", "job_id", t.JobID, "format", t.Format, "error", err)
+
+	body := `
+    <!DOCTYPE html>
+    <html>
+    <body style="font-family: Arial, sans-serif; line-height: 1.6;">
+        <h2>Your export could not be completed</h2>
+        <p>Something went wrong while preparing your testimonial export. Please try again later.</p>
+        <br>
+        <p>Blessings,<br>The Wisdom House Team</p>
+    </body>
+    </html>`
+	if sendErr := t.sender.SendHTML(ctx, t.Email, "Your testimonial export failed", body); sendErr != nil {
+		slog.ErrorContext(ctx, "failed to report export failure", "job_id", t.JobID, "error", sendErr)
+	}
+}
+
+func (t *ExportTask) Name() string {
+	return fmt.Sprintf("export_task_%s", t.JobID)
+}
+
+func (t *ExportTask) Type() string {
+	return "export"
+}
+
+func (t *ExportTask) ID() string {
+	return t.JobID.String()
+}
+
+func (t *ExportTask) RetryCount() int {
+	return t.Retries
+}
@@ -0,0 +1,52 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"wisdomHouse-backend/internal/storage"
+)
+
+// PurgeExportsTask deletes exports whose download links have expired.
+type PurgeExportsTask struct {
+	LinkTTL time.Duration
+	Timeout time.Duration
+	storage storage.Storage
+}
+
+func NewPurgeExportsTask(store storage.Storage, linkTTL time.Duration) *PurgeExportsTask {
+	return &PurgeExportsTask{
+		LinkTTL: linkTTL,
+		Timeout: 5 * time.Minute,
+		storage: store,
+	}
+}
+
+func (t *PurgeExportsTask) Execute() error {
+	ctx, cancel := context.WithTimeout(context.Background(), t.Timeout)
+	defer cancel()
+
+	cutoff := time.Now().Add(-t.LinkTTL)
+	purged, err := t.storage.DeletePrivateBefore(ctx, exportKeyPrefix, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to purge exports: %w", err)
+	}
+	if purged > 0 {
+		slog.InfoContext(ctx, "purged expired exports", "count", purged, "stored_before", cutoff)
+	}
+	return nil
+}
+
+func (t *PurgeExportsTask) Name() string {
+	return "purge_exports"
+}
+
+func (t *PurgeExportsTask) Type() string {
+	return "purge_exports"
+}
+
+func (t *PurgeExportsTask) RetryCount() int {
+	return 2
+}
@@ -0,0 +1,53 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"wisdomHouse-backend/internal/repository"
+)
+
+// PurgeTrashTask permanently deletes testimonials that have been in the trash
+// longer than the retention period.
+type PurgeTrashTask struct {
+	Retention time.Duration
+	Timeout   time.Duration
+	repo      repository.TestimonialRepository
+}
+
+func NewPurgeTrashTask(repo repository.TestimonialRepository, retention time.Duration) *PurgeTrashTask {
+	return &PurgeTrashTask{
+		Retention: retention,
+		Timeout:   5 * time.Minute,
+		repo:      repo,
+	}
+}
+
+func (t *PurgeTrashTask) Execute() error {
+	ctx, cancel := context.WithTimeout(context.Background(), t.Timeout)
+	defer cancel()
+
+	cutoff := time.Now().Add(-t.Retention)
+	purged, err := t.repo.PurgeDeletedBefore(ctx, cutoff)
+	if err != nil {
+		return fmt.Errorf("failed to purge trash: %w", err)
+	}
+	if purged > 0 {
+		slog.InfoContext(ctx, "purged testimonials from trash", "count", purged, "deleted_before", cutoff)
+	}
+	return nil
+}
+
+func (t *PurgeTrashTask) Name() string {
+	return "purge_trash"
+}
+
+func (t *PurgeTrashTask) Type() string {
+	return "purge_trash"
+}
+
+func (t *PurgeTrashTask) RetryCount() int {
+	return 2
+}
@@ -0,0 +1,136 @@
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"wisdomHouse-backend/internal/media"
+	"wisdomHouse-backend/internal/models"
+	"wisdomHouse-backend/internal/repository"
+	"wisdomHouse-backend/internal/storage"
+	"wisdomHouse-backend/internal/worker"
+)
+
+// TranscodeTask probes and transcodes an uploaded recording, stores the
+// web-friendly rendition and tracks progress on the media record. It owns
+// SourcePath and removes it once finished.
+type TranscodeTask struct {
+	worker.RequestContext
+	MediaID    uuid.UUID
+	SourcePath string
+	Retries    int
+	Timeout    time.Duration
+	repo       repository.MediaRepository
+	storage    storage.Storage
+	transcoder media.Transcoder
+}
+
+func NewTranscodeTask(ctx context.Context, repo repository.MediaRepository, store storage.Storage, transcoder media.Transcoder, mediaID uuid.UUID, sourcePath string) *TranscodeTask {
+	return &TranscodeTask{
+		RequestContext: worker.NewRequestContext(ctx),
+		MediaID:        mediaID,
+		SourcePath:     sourcePath,
+		Retries:        2,
+		Timeout:        30 * time.Minute,
+		repo:           repo,
+		storage:        store,
+		transcoder:     transcoder,
+	}
+}
+
+func (t *TranscodeTask) Execute() error {
+	ctx, cancel := context.WithTimeout(t.Context(), t.Timeout)
+	defer cancel()
+
+	record, err := t.repo.GetByID(ctx, t.MediaID)
+	if err != nil {
+		return fmt.Errorf("failed to load media %s: %w", t.MediaID, err)
+	}
+
+	record.TranscodeStatus = models.TranscodeProcessing
+	if err := t.repo.Update(ctx, record); err != nil {
+		return err
+	}
+
+	duration, err := t.transcoder.Probe(ctx, t.SourcePath)
+	if err != nil {
+		return err
+	}
+
+	result, err := t.transcoder.Transcode(ctx, t.SourcePath, record.Kind)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(result.Path)
+
+	url, err := t.store(ctx, record, result)
+	if err != nil {
+		return err
+	}
+
+	record.DurationSeconds = &duration
+	record.TranscodedURL = &url
+	record.TranscodeStatus = models.TranscodeReady
+	record.TranscodeError = nil
+	if err := t.repo.Update(ctx, record); err != nil {
+		return err
+	}
+
+	os.Remove(t.SourcePath)
+	return nil
+}
+
+func (t *TranscodeTask) store(ctx context.Context, record *models.TestimonialMedia, result *media.TranscodeResult) (string, error) {
+	file, err := os.Open(result.Path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("testimonials/%s/media/%s/transcoded%s", record.TestimonialID, record.ID, result.Extension)
+	return t.storage.Put(ctx, key, file, info.Size(), result.ContentType)
+}
+
+// OnFailure marks the media record as failed and discards the source file.
+func (t *TranscodeTask) OnFailure(err error) {
+	defer os.Remove(t.SourcePath)
+	ctx := t.Context()
+
+	record, getErr := t.repo.GetByID(ctx, t.MediaID)
+	if getErr != nil {
+		slog.ErrorContext(ctx, "failed to load media to record transcode failure", "media_id", t.MediaID, "error", getErr)
+		return
+	}
+
+	message := err.Error()
+	record.TranscodeStatus = models.TranscodeFailed
+	record.TranscodeError = &message
+	if updateErr := t.repo.Update(ctx, record); updateErr != nil {
+		slog.ErrorContext(ctx, "failed to record transcode failure", "media_id", t.MediaID, "error", updateErr)
+	}
+}
+
+func (t *TranscodeTask) Name() string {
+	return fmt.Sprintf("transcode_task_%s", t.MediaID)
+}
+
+func (t *TranscodeTask) Type() string {
+	return "transcode"
+}
+
+func (t *TranscodeTask) ID() string {
+	return t.MediaID.String()
+}
+
+func (t *TranscodeTask) RetryCount() int {
+	return t.Retries
+}
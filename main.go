@@ -1,19 +1,38 @@
 ﻿package main
 
 import (
+	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"log/slog"
+	"os"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
+	"wisdomHouse-backend/internal/audit"
+	"wisdomHouse-backend/internal/cache"
 	"wisdomHouse-backend/internal/config"
 	"wisdomHouse-backend/internal/database"
+	"wisdomHouse-backend/internal/email"
 	"wisdomHouse-backend/internal/handlers"
+	"wisdomHouse-backend/internal/logging"
+	"wisdomHouse-backend/internal/media"
+	"wisdomHouse-backend/internal/metrics"
 	"wisdomHouse-backend/internal/middleware"
+	"wisdomHouse-backend/internal/models"
 	"wisdomHouse-backend/internal/repository"
+	"wisdomHouse-backend/internal/screening"
 	"wisdomHouse-backend/internal/service"
+	"wisdomHouse-backend/internal/storage"
+	"wisdomHouse-backend/internal/tracing"
+	"wisdomHouse-backend/internal/validation"
+	"wisdomHouse-backend/internal/worker"
+	"wisdomHouse-backend/internal/worker/tasks"
 )
 
 // @title Wisdom House Backend API
@@ -25,22 +44,53 @@ import (
 // @in header
 // @name Authorization
 func main() {
-	// Load configuration
-	cfg, err := config.Load()
+	// Load configuration; "config" prints the effective settings and exits,
+	// "import" loads testimonials from a file and exits
+	args := os.Args[1:]
+	dumpConfig := len(args) > 0 && args[0] == "config"
+	if dumpConfig {
+		args = args[1:]
+	}
+	var importArgs []string
+	if len(args) > 0 && args[0] == "import" {
+		importArgs, args = args[1:], nil
+	}
+
+	cfg, err := config.Load(args)
 	if err != nil {
 		log.Fatalf("❌ Failed to load config: %v", err)
 	}
 
+	if dumpConfig {
+		if err := cfg.Dump(os.Stdout); err != nil {
+			log.Fatalf("❌ Failed to print config: %v", err)
+		}
+		return
+	}
+
 	// Set Gin mode
 	gin.SetMode(cfg.Server.GinMode)
 
+	logger := logging.New(os.Stdout, cfg.App.LogLevel, cfg.App.LogFormat)
+	slog.SetDefault(logger)
+
+	if err := validation.Register(); err != nil {
+		log.Fatalf("❌ Failed to register validators: %v", err)
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), &cfg.Tracing)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize tracing: %v", err)
+	}
+	defer shutdownTracing(context.Background())
+
 	log.Println("🚀 Starting Wisdom House Backend API")
 	log.Printf("📡 Port: %s", cfg.Server.Port)
 	log.Printf("🗄️  Database: %s:%s/%s", cfg.Database.Host, cfg.Database.Port, cfg.Database.DBName)
 
 	// 1. Connect to Database
 	log.Println("🔌 Connecting to database...")
-	db, err := database.NewDatabase(&cfg.Database)
+	db, err := database.NewDatabase(&cfg.Database, logger)
 	if err != nil {
 		log.Fatalf("❌ Failed to connect to database: %v", err)
 	}
@@ -53,26 +103,140 @@ func main() {
 	}
 	log.Println("✅ Database connection verified")
 
-	// 3. Initialize repository, service, and handlers
+	if importArgs != nil {
+		if err := runImport(db, importArgs); err != nil {
+			log.Fatalf("❌ Import failed: %v", err)
+		}
+		return
+	}
+
+	// 3. Initialize storage and background workers
+	fileStorage, err := storage.New(&cfg.Storage)
+	if err != nil {
+		log.Fatalf("❌ Failed to initialize storage: %v", err)
+	}
+
+	workerPool := worker.NewWorkerPool(cfg.Worker.PoolSize)
+	workerPool.Start()
+	defer workerPool.Shutdown()
+
+	transcoder := media.NewFFmpegTranscoder(cfg.Media.FFmpegPath, cfg.Media.FFprobePath, cfg.Upload.TempDir)
+
+	// 4. Initialize repositories, services, and handlers
 	testimonialRepo := repository.NewTestimonialRepository(db)
-	testimonialService := service.NewTestimonialService(testimonialRepo)
-	testimonialHandler := handlers.NewTestimonialHandler(testimonialService)
+	mediaRepo := repository.NewMediaRepository(db)
+	revisionRepo := repository.NewRevisionRepository(db)
+	reactionRepo := repository.NewReactionRepository(db)
+	commentRepo := repository.NewCommentRepository(db)
+	unitOfWork := repository.NewUnitOfWork(db)
+
+	// Purge the trash and expired exports on a schedule; cancelled before the
+	// pool shuts down
+	scheduleCtx, stopSchedules := context.WithCancel(context.Background())
+	defer stopSchedules()
+	if cfg.Trash.Retention > 0 {
+		workerPool.Every(scheduleCtx, cfg.Trash.PurgeInterval, func() worker.Task {
+			return tasks.NewPurgeTrashTask(testimonialRepo, cfg.Trash.Retention)
+		})
+	}
+	workerPool.Every(scheduleCtx, cfg.Exports.CleanupInterval, func() worker.Task {
+		return tasks.NewPurgeExportsTask(fileStorage, cfg.Exports.LinkTTL)
+	})
+
+	// Redis caches responses and deduplicates reactions; without it the API
+	// still runs, uncached and with reactions unavailable
+	featured := service.FeaturedOptions{Limit: cfg.Featured.Limit, TTL: cfg.Featured.CacheTTL}
+	var reactionSets service.ReactionSets
+	if cfg.Redis.Enabled {
+		redisClient, err := cache.NewRedisClient(context.Background(), cfg.Redis.URL, cfg.Redis.Password, cfg.Redis.PoolSize)
+		if err != nil {
+			logger.Warn("redis unavailable, serving without cache or reactions", "error", err)
+		} else {
+			defer redisClient.Close()
+			featured.Cache = redisClient
+			reactionSets = redisClient
+		}
+	}
+
+	// Comment notifications and export links are only emailed when SMTP is configured
+	var mailer tasks.Sender
+	if cfg.SMTP.Enabled {
+		sender, err := email.NewSender(&cfg.SMTP, cfg.Redis.URL)
+		if err != nil {
+			log.Fatalf("❌ Failed to initialize email sender: %v", err)
+		}
+		mailer = sender
+	}
+
+	screener := newScreeningPipeline(&cfg.Screening, testimonialRepo)
+	commentScreener := newCommentScreeningPipeline(&cfg.Screening, &cfg.Comments)
+	transcriptScreener := newTranscriptScreeningPipeline(&cfg.Screening)
+	testimonialService := service.NewTestimonialService(testimonialRepo, revisionRepo, unitOfWork, fileStorage, screener, cfg.Upload.MaxImageBytes, featured)
+	reactionService := service.NewReactionService(testimonialRepo, reactionRepo, reactionSets, cfg.Reactions.FingerprintSecret, cfg.Reactions.DedupTTL)
+	mediaService := service.NewMediaService(testimonialRepo, mediaRepo, fileStorage, transcoder, workerPool, transcriptScreener, cfg.Upload.MaxMediaBytes)
+	commentService := service.NewCommentService(testimonialRepo, commentRepo, unitOfWork, commentScreener, workerPool, mailer)
+	exportService := service.NewExportService(testimonialRepo, fileStorage, workerPool, mailer, cfg.Upload.TempDir, cfg.Exports.LinkTTL)
+	importService := service.NewImportService(testimonialRepo, revisionRepo, unitOfWork)
+	feedService := service.NewFeedService(testimonialRepo, service.FeedOptions{
+		Title:       cfg.Feeds.Title,
+		Description: cfg.Feeds.Description,
+		SiteURL:     cfg.Feeds.SiteURL,
+		BaseURL:     cfg.Feeds.BaseURL,
+		Limit:       cfg.Feeds.Limit,
+	})
 
-	// 4. Setup Gin router
+	testimonialHandler := handlers.NewTestimonialHandler(testimonialService, cfg.Upload.MaxImageBytes)
+	reactionHandler := handlers.NewReactionHandler(reactionService)
+	mediaHandler := handlers.NewMediaHandler(mediaService, cfg.Upload.MaxMediaBytes, cfg.Upload.TempDir)
+	commentHandler := handlers.NewCommentHandler(commentService)
+	exportHandler := handlers.NewExportHandler(exportService)
+	importHandler := handlers.NewImportHandler(importService, cfg.Upload.MaxImportBytes)
+	feedHandler := handlers.NewFeedHandler(feedService, cfg.Feeds.MaxAge)
+
+	// 5. Setup Gin router
 	router := gin.New()
+	// Only configured proxies may set the client IP used for reaction
+	// dedup, CAPTCHA checks and logs; nil trusts none.
+	if err := router.SetTrustedProxies(cfg.Server.TrustedProxies); err != nil {
+		log.Fatalf("❌ Invalid trusted proxies: %v", err)
+	}
 
 	// Middleware
-	router.Use(gin.Recovery())
+	router.Use(middleware.RequestID())
+	router.Use(middleware.Actor())
+	router.Use(middleware.Locale())
+	router.Use(middleware.Tracing())
 	router.Use(middleware.Logger())
+	router.Use(middleware.Metrics())
+	// Inside Logger and Metrics so a recovered panic is logged and counted as a 500
+	router.Use(middleware.Recovery())
 	router.Use(middleware.CORS(&cfg.CORS))
+	router.Use(middleware.ErrorHandler())
+	router.NoRoute(middleware.NoRoute)
+
+	// 6. Routes
+	setupRoutes(router, testimonialHandler, mediaHandler, reactionHandler, commentHandler, exportHandler, importHandler, feedHandler)
+
+	// Locally stored uploads are served by the API itself, private files only
+	// through signed download links
+	if localStorage, ok := fileStorage.(*storage.LocalStorage); ok {
+		router.Static("/uploads", cfg.Storage.LocalDir)
+		router.GET("/downloads/*key", handlers.NewDownloadHandler(localStorage).Download)
+	}
 
-	// 5. Routes
-	setupRoutes(router, testimonialHandler)
+	// Prometheus metrics, on the API port or a separate admin port
+	if cfg.Metrics.Enabled {
+		if cfg.Metrics.Port == "" {
+			router.GET(cfg.Metrics.Path, middleware.MetricsAuth(cfg.Metrics.Token), gin.WrapH(metrics.Handler()))
+		} else {
+			go serveMetrics(&cfg.Metrics)
+		}
+	}
 
 	// Swagger documentation
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	// 6. Start server
+	// 7. Start server
 	log.Printf("✅ Server is ready: http://localhost:%s", cfg.Server.Port)
 	log.Printf("📊 Health check: http://localhost:%s/health", cfg.Server.Port)
 	log.Printf("🗣️  Testimonials: http://localhost:%s/api/v1/testimonials", cfg.Server.Port)
@@ -93,7 +257,139 @@ func verifyDatabaseConnection(db *database.Database) error {
 	return nil
 }
 
-func setupRoutes(router *gin.Engine, testimonialHandler *handlers.TestimonialHandler) {
+// serveMetrics exposes Prometheus metrics on a dedicated admin port so they
+// can be kept off the public listener.
+func serveMetrics(cfg *config.MetricsConfig) {
+	admin := gin.New()
+	admin.Use(middleware.Recovery())
+	admin.GET(cfg.Path, middleware.MetricsAuth(cfg.Token), gin.WrapH(metrics.Handler()))
+
+	log.Printf("📈 Metrics: http://localhost:%s%s", cfg.Port, cfg.Path)
+	if err := admin.Run(":" + cfg.Port); err != nil {
+		log.Fatalf("❌ Failed to start metrics server: %v", err)
+	}
+}
+
+// newScreeningPipeline assembles the spam and abuse rules applied to public
+// submissions. Cheap rejections run first so bots never reach the database.
+func newScreeningPipeline(cfg *config.ScreeningConfig, repo repository.TestimonialRepository) *screening.Pipeline {
+	rules := []screening.Rule{screening.HoneypotRule{}}
+	if cfg.CaptchaVerifyURL != "" {
+		rules = append(rules, screening.NewCaptchaRule(screening.NewSiteVerifyCaptcha(cfg.CaptchaVerifyURL, cfg.CaptchaSecret)))
+	}
+	rules = append(rules,
+		screening.LengthRule{Min: cfg.MinLength, Max: cfg.MaxLength},
+		screening.NewBlocklistRule(cfg.Blocklist),
+		screening.LinkCountRule{Max: cfg.MaxLinks},
+		screening.NewDuplicateRule(repo),
+	)
+	return screening.NewPipeline(rules...)
+}
+
+// newCommentScreeningPipeline applies the testimonial rules to comments with
+// comment length limits. Short replies such as "Amen!" are common, so
+// repeated content is not treated as spam.
+func newCommentScreeningPipeline(cfg *config.ScreeningConfig, comments *config.CommentsConfig) *screening.Pipeline {
+	rules := []screening.Rule{screening.HoneypotRule{}}
+	if cfg.CaptchaVerifyURL != "" {
+		rules = append(rules, screening.NewCaptchaRule(screening.NewSiteVerifyCaptcha(cfg.CaptchaVerifyURL, cfg.CaptchaSecret)))
+	}
+	rules = append(rules,
+		screening.LengthRule{Min: comments.MinLength, Max: comments.MaxLength, Label: "comment"},
+		screening.NewBlocklistRule(cfg.Blocklist),
+		screening.LinkCountRule{Max: cfg.MaxLinks},
+	)
+	return screening.NewPipeline(rules...)
+}
+
+// newTranscriptScreeningPipeline applies the content rules to transcripts.
+// They arrive with a recording rather than a form post, and their length is
+// limited by the request binding.
+func newTranscriptScreeningPipeline(cfg *config.ScreeningConfig) *screening.Pipeline {
+	return screening.NewPipeline(
+		screening.NewBlocklistRule(cfg.Blocklist),
+		screening.LinkCountRule{Max: cfg.MaxLinks},
+	)
+}
+
+// importActor is recorded in the revision history of imported testimonials.
+const importActor = "import"
+
+// runImport implements the "import" command:
+//
+//	wisdom-house import [-dry-run] [-format csv|json] FILE
+//
+// It loads a CSV or JSON file of testimonials and prints a row-level report.
+// Configuration comes from the config file and environment only.
+func runImport(db *database.Database, args []string) error {
+	flags := flag.NewFlagSet("import", flag.ContinueOnError)
+	dryRun := flags.Bool("dry-run", false, "validate and report without importing")
+	format := flags.String("format", "", "csv or json; inferred from the file extension when empty")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: wisdom-house import [-dry-run] [-format csv|json] FILE")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() != 1 {
+		flags.Usage()
+		return errors.New("exactly one file is required")
+	}
+
+	path := flags.Arg(0)
+	importFormat := models.ImportFormat(*format)
+	if importFormat == "" {
+		importFormat = models.ImportFormatForFile(path)
+	}
+	if importFormat != models.ImportCSV && importFormat != models.ImportJSON {
+		return fmt.Errorf("cannot tell the format of %s; pass -format csv or -format json", path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	importService := service.NewImportService(
+		repository.NewTestimonialRepository(db),
+		repository.NewRevisionRepository(db),
+		repository.NewUnitOfWork(db),
+	)
+	ctx := audit.WithActor(context.Background(), importActor)
+	result, err := importService.ImportTestimonials(ctx, importFormat, file, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	printImportResult(result)
+	return nil
+}
+
+func printImportResult(result *models.ImportResult) {
+	for _, row := range result.Rows {
+		if row.Status == models.ImportCreated {
+			continue
+		}
+		fmt.Printf("row %d: %s: %s\n", row.Row, row.Status, row.Message)
+		for _, field := range row.Errors {
+			fmt.Printf("    %s %s\n", field.Field, field.Message)
+		}
+	}
+
+	verb := "imported"
+	if result.DryRun {
+		verb = "would import"
+	}
+	summary := []string{
+		fmt.Sprintf("%d duplicates", result.Duplicates),
+		fmt.Sprintf("%d invalid", result.Invalid),
+	}
+	fmt.Printf("%s %d of %d rows (%s)\n", verb, result.Created, result.Total, strings.Join(summary, ", "))
+}
+
+func setupRoutes(router *gin.Engine, testimonialHandler *handlers.TestimonialHandler, mediaHandler *handlers.MediaHandler, reactionHandler *handlers.ReactionHandler, commentHandler *handlers.CommentHandler, exportHandler *handlers.ExportHandler, importHandler *handlers.ImportHandler, feedHandler *handlers.FeedHandler) {
 	// Health check
 	router.GET("/", func(c *gin.Context) {
 		c.JSON(200, gin.H{
@@ -112,6 +408,18 @@ func setupRoutes(router *gin.Engine, testimonialHandler *handlers.TestimonialHan
 		})
 	})
 
+	// Public syndication feeds, at stable URLs outside the versioned API so
+	// subscriptions survive API upgrades
+	feeds := router.Group("/feeds")
+	{
+		feeds.GET("testimonials.rss", feedHandler.RSS)
+		feeds.GET("testimonials.atom", feedHandler.Atom)
+		feeds.GET("testimonials.json", feedHandler.JSON)
+		feeds.GET("tags/:tag/testimonials.rss", feedHandler.RSS)
+		feeds.GET("tags/:tag/testimonials.atom", feedHandler.Atom)
+		feeds.GET("tags/:tag/testimonials.json", feedHandler.JSON)
+	}
+
 	// API v1 routes
 	api := router.Group("/api/v1")
 	{
@@ -121,10 +429,52 @@ func setupRoutes(router *gin.Engine, testimonialHandler *handlers.TestimonialHan
 			testimonials.POST("", testimonialHandler.CreateTestimonial)
 			testimonials.GET("", testimonialHandler.GetAllTestimonials)
 			testimonials.GET("paginated", testimonialHandler.GetPaginatedTestimonials)
+			testimonials.GET("featured", testimonialHandler.GetFeaturedTestimonials)
 			testimonials.GET("/:id", testimonialHandler.GetTestimonialByID)
 			testimonials.PUT("/:id", testimonialHandler.UpdateTestimonial)
 			testimonials.DELETE("/:id", testimonialHandler.DeleteTestimonial)
 			testimonials.PATCH("/:id/approve", testimonialHandler.ApproveTestimonial)
+			testimonials.POST("/:id/image", testimonialHandler.UploadTestimonialImage)
+			testimonials.POST("/:id/media", mediaHandler.UploadTestimonialMedia)
+			testimonials.POST("/:id/reactions", reactionHandler.React)
+			testimonials.DELETE("/:id/reactions/:kind", reactionHandler.Unreact)
+			testimonials.GET("/:id/comments", commentHandler.GetComments)
+			testimonials.POST("/:id/comments", commentHandler.CreateComment)
+		}
+
+		// Moderator endpoints (expose the submitter's real identity)
+		admin := api.Group("/admin")
+		{
+			adminTestimonials := admin.Group("/testimonials")
+			adminTestimonials.GET("", testimonialHandler.AdminGetAllTestimonials)
+			adminTestimonials.GET("paginated", testimonialHandler.AdminGetPaginatedTestimonials)
+			adminTestimonials.GET("trash", testimonialHandler.AdminGetDeletedTestimonials)
+			adminTestimonials.POST("bulk", testimonialHandler.BulkModerate)
+			adminTestimonials.GET("export", exportHandler.ExportTestimonials)
+			adminTestimonials.POST("exports", exportHandler.QueueExport)
+			adminTestimonials.POST("import", importHandler.ImportTestimonials)
+			adminTestimonials.GET("/:id", testimonialHandler.AdminGetTestimonialByID)
+			adminTestimonials.PUT("/:id", testimonialHandler.UpdateTestimonial)
+			adminTestimonials.DELETE("/:id", testimonialHandler.DeleteTestimonial)
+			adminTestimonials.PATCH("/:id/approve", testimonialHandler.ApproveTestimonial)
+			adminTestimonials.PUT("/:id/feature", testimonialHandler.FeatureTestimonial)
+			adminTestimonials.POST("/:id/image", testimonialHandler.AdminUploadTestimonialImage)
+			adminTestimonials.PUT("/:id/translations/:lang", testimonialHandler.SaveTranslation)
+			adminTestimonials.DELETE("/:id/translations/:lang", testimonialHandler.DeleteTranslation)
+			adminTestimonials.GET("/:id/revisions", testimonialHandler.GetTestimonialRevisions)
+			adminTestimonials.POST("/:id/revisions/:revisionId/revert", testimonialHandler.RevertTestimonial)
+			adminTestimonials.POST("/:id/restore", testimonialHandler.RestoreTestimonial)
+			adminTestimonials.DELETE("/:id/purge", testimonialHandler.PurgeTestimonial)
+			adminTestimonials.GET("/:id/media", mediaHandler.GetTestimonialMedia)
+			adminTestimonials.PUT("/:id/media/:mediaId/transcript", mediaHandler.UpdateTranscript)
+			adminTestimonials.PATCH("/:id/media/:mediaId/approve", mediaHandler.ApproveMedia)
+			adminTestimonials.DELETE("/:id/media/:mediaId", mediaHandler.DeleteMedia)
+			adminTestimonials.GET("/:id/comments", commentHandler.AdminGetTestimonialComments)
+			adminTestimonials.PATCH("/:id/comments/:commentId/approve", commentHandler.ApproveComment)
+			adminTestimonials.PATCH("/:id/comments/:commentId/reject", commentHandler.RejectComment)
+			adminTestimonials.DELETE("/:id/comments/:commentId", commentHandler.DeleteComment)
+
+			admin.GET("/comments", commentHandler.AdminGetComments)
 		}
 
 		// Simple ping endpoint
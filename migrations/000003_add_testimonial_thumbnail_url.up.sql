@@ -0,0 +1 @@
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS thumbnail_url VARCHAR(500);
@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS update_testimonial_media_updated_at ON testimonial_media;
+DROP INDEX IF EXISTS idx_testimonial_media_deleted_at;
+DROP INDEX IF EXISTS idx_testimonial_media_testimonial_id;
+DROP TABLE IF EXISTS testimonial_media;
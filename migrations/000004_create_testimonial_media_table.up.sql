@@ -0,0 +1,23 @@
+CREATE TABLE IF NOT EXISTS testimonial_media (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    testimonial_id UUID NOT NULL REFERENCES testimonials(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('video', 'audio')),
+    mime_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    duration_seconds DOUBLE PRECISION,
+    original_url VARCHAR(500) NOT NULL,
+    transcoded_url VARCHAR(500),
+    transcode_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    transcode_error TEXT,
+    transcript TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_testimonial_media_testimonial_id ON testimonial_media(testimonial_id);
+CREATE INDEX IF NOT EXISTS idx_testimonial_media_deleted_at ON testimonial_media(deleted_at);
+
+CREATE TRIGGER update_testimonial_media_updated_at
+    BEFORE UPDATE ON testimonial_media
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_testimonials_content_hash;
+DROP INDEX IF EXISTS idx_testimonials_flagged;
+
+ALTER TABLE testimonials DROP COLUMN IF EXISTS content_hash;
+ALTER TABLE testimonials DROP COLUMN IF EXISTS flag_reasons;
+ALTER TABLE testimonials DROP COLUMN IF EXISTS is_flagged;
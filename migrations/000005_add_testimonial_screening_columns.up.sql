@@ -0,0 +1,6 @@
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS is_flagged BOOLEAN DEFAULT FALSE;
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS flag_reasons TEXT;
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+
+CREATE INDEX IF NOT EXISTS idx_testimonials_flagged ON testimonials(is_flagged);
+CREATE INDEX IF NOT EXISTS idx_testimonials_content_hash ON testimonials(content_hash);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_testimonials_tags;
+DROP INDEX IF EXISTS idx_testimonials_featured;
+
+ALTER TABLE testimonials DROP COLUMN IF EXISTS rejected_at;
+ALTER TABLE testimonials DROP COLUMN IF EXISTS tags;
+ALTER TABLE testimonials DROP COLUMN IF EXISTS is_featured;
@@ -0,0 +1,6 @@
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS is_featured BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS tags JSONB NOT NULL DEFAULT '[]'::jsonb;
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS rejected_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_testimonials_featured ON testimonials(is_featured);
+CREATE INDEX IF NOT EXISTS idx_testimonials_tags ON testimonials USING GIN (tags);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_testimonials_feature_order;
+
+ALTER TABLE testimonials DROP COLUMN IF EXISTS featured_until;
+ALTER TABLE testimonials DROP COLUMN IF EXISTS featured_from;
+ALTER TABLE testimonials DROP COLUMN IF EXISTS feature_order;
@@ -0,0 +1,5 @@
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS feature_order INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS featured_from TIMESTAMP WITH TIME ZONE;
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS featured_until TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX IF NOT EXISTS idx_testimonials_feature_order ON testimonials(feature_order) WHERE is_featured;
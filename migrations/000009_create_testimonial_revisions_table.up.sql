@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS testimonial_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    testimonial_id UUID NOT NULL REFERENCES testimonials(id) ON DELETE CASCADE,
+    version BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    request_id VARCHAR(128),
+    changes JSONB NOT NULL DEFAULT '{}'::jsonb,
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_testimonial_revisions_testimonial_id ON testimonial_revisions(testimonial_id, version DESC);
+
+-- Baseline revision for existing testimonials so their current wording can be reverted to
+INSERT INTO testimonial_revisions (testimonial_id, version, action, actor, snapshot, created_at)
+SELECT id, version, 'create', 'system',
+    jsonb_build_object(
+        'firstName', first_name,
+        'lastName', last_name,
+        'testimony', testimony,
+        'imageUrl', image_url,
+        'thumbnailUrl', thumbnail_url,
+        'isAnonymous', COALESCE(is_anonymous, FALSE),
+        'isApproved', COALESCE(is_approved, FALSE),
+        'isFlagged', COALESCE(is_flagged, FALSE),
+        'isFeatured', is_featured,
+        'tags', tags
+    ),
+    COALESCE(updated_at, created_at, CURRENT_TIMESTAMP)
+FROM testimonials;
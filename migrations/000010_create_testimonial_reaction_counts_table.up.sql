@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS testimonial_reaction_counts (
+    testimonial_id UUID NOT NULL REFERENCES testimonials(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('amen', 'praise', 'prayed')),
+    count BIGINT NOT NULL DEFAULT 0 CHECK (count >= 0),
+    PRIMARY KEY (testimonial_id, kind)
+);
@@ -0,0 +1,2 @@
+DROP TRIGGER IF EXISTS update_testimonial_comments_updated_at ON testimonial_comments;
+DROP TABLE IF EXISTS testimonial_comments;
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS testimonial_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    testimonial_id UUID NOT NULL REFERENCES testimonials(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES testimonial_comments(id) ON DELETE CASCADE,
+    author_name VARCHAR(100) NOT NULL,
+    author_email VARCHAR(254),
+    body TEXT NOT NULL,
+    is_approved BOOLEAN NOT NULL DEFAULT FALSE,
+    is_flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    flag_reasons TEXT,
+    rejected_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_testimonial_comments_testimonial_id ON testimonial_comments(testimonial_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_testimonial_comments_parent_id ON testimonial_comments(parent_id);
+CREATE INDEX IF NOT EXISTS idx_testimonial_comments_moderation ON testimonial_comments(is_approved, is_flagged);
+CREATE INDEX IF NOT EXISTS idx_testimonial_comments_deleted_at ON testimonial_comments(deleted_at);
+
+CREATE TRIGGER update_testimonial_comments_updated_at
+    BEFORE UPDATE ON testimonial_comments
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
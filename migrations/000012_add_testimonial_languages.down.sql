@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS update_testimonial_translations_updated_at ON testimonial_translations;
+DROP TABLE IF EXISTS testimonial_translations;
+ALTER TABLE testimonials DROP COLUMN IF EXISTS language;
@@ -0,0 +1,14 @@
+ALTER TABLE testimonials ADD COLUMN IF NOT EXISTS language VARCHAR(35) NOT NULL DEFAULT 'en';
+
+CREATE TABLE IF NOT EXISTS testimonial_translations (
+    testimonial_id UUID NOT NULL REFERENCES testimonials(id) ON DELETE CASCADE,
+    language VARCHAR(35) NOT NULL,
+    testimony TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (testimonial_id, language)
+);
+
+CREATE TRIGGER update_testimonial_translations_updated_at
+    BEFORE UPDATE ON testimonial_translations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
@@ -0,0 +1,3 @@
+ALTER TABLE testimonial_media DROP COLUMN IF EXISTS flag_reasons;
+ALTER TABLE testimonial_media DROP COLUMN IF EXISTS is_flagged;
+ALTER TABLE testimonial_media DROP COLUMN IF EXISTS is_approved;
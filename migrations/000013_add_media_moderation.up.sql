@@ -0,0 +1,5 @@
+-- Recordings are hidden from the public until a moderator approves them.
+-- Existing recordings were never reviewed, so they start out unapproved too.
+ALTER TABLE testimonial_media ADD COLUMN IF NOT EXISTS is_approved BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE testimonial_media ADD COLUMN IF NOT EXISTS is_flagged BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE testimonial_media ADD COLUMN IF NOT EXISTS flag_reasons TEXT;
@@ -1,16 +1,16 @@
 package utils
 
 import (
-    
+    "net/http"
 
     "github.com/gin-gonic/gin"
+    "wisdomHouse-backend/internal/i18n"
 )
 
 type Response struct {
     Success bool        `json:"success"`
     Message string      `json:"message"`
     Data    interface{} `json:"data,omitempty"`
-    Error   string      `json:"error,omitempty"`
 }
 
 type PaginatedResponse struct {
@@ -23,30 +23,62 @@ type PaginatedResponse struct {
     LastPage int         `json:"last_page"`
 }
 
+// Problem is an RFC 7807 problem details body. Code is a stable,
+// machine-readable identifier; Detail is for humans and may change.
+type Problem struct {
+    Type      string       `json:"type"`
+    Title     string       `json:"title"`
+    Status    int          `json:"status"`
+    Detail    string       `json:"detail,omitempty"`
+    Instance  string       `json:"instance,omitempty"`
+    Code      string       `json:"code"`
+    RequestID string       `json:"requestId,omitempty"`
+    Errors    []FieldError `json:"errors,omitempty"`
+}
+
+// FieldError describes why a single request field was rejected.
+type FieldError struct {
+    Field   string `json:"field"`
+    Rule    string `json:"rule"`
+    Message string `json:"message"`
+}
+
+const ProblemContentType = "application/problem+json"
+
+// SuccessResponse renders message, an i18n message key, in the caller's
+// language. Text that is not a key is sent as is.
 func SuccessResponse(c *gin.Context, statusCode int, message string, data interface{}) {
     response := Response{
         Success: true,
-        Message: message,
+        Message: localize(c, message),
         Data:    data,
     }
     c.JSON(statusCode, response)
 }
 
-func ErrorResponse(c *gin.Context, statusCode int, message string) {
-    response := Response{
-        Success: false,
-        Message: message,
-        Error:   message,
+// ProblemResponse writes problem as application/problem+json and aborts the
+// remaining handlers.
+func ProblemResponse(c *gin.Context, problem Problem) {
+    if problem.Type == "" {
+        problem.Type = "about:blank"
     }
-    c.JSON(statusCode, response)
+    if problem.Title == "" {
+        problem.Title = http.StatusText(problem.Status)
+    }
+    // gin keeps a Content-Type that is already set when rendering JSON
+    c.Header("Content-Type", ProblemContentType)
+    c.AbortWithStatusJSON(problem.Status, problem)
 }
 
 func PaginatedSuccessResponse(c *gin.Context, statusCode int, data interface{}, page, limit int, total int64) {
-    lastPage := int((total + int64(limit) - 1) / int64(limit))
+    lastPage := 0
+    if limit > 0 {
+        lastPage = int((total + int64(limit) - 1) / int64(limit))
+    }
     
     response := PaginatedResponse{
         Success:  true,
-        Message:  "Data fetched successfully",
+        Message:  localize(c, i18n.MsgDataFetched),
         Data:     data,
         Page:     page,
         Limit:    limit,
@@ -54,4 +86,12 @@ func PaginatedSuccessResponse(c *gin.Context, statusCode int, data interface{},
         LastPage: lastPage,
     }
     c.JSON(statusCode, response)
+}
+
+// localize translates a message key and records the language it was
+// rendered in.
+func localize(c *gin.Context, key string) string {
+    ctx := c.Request.Context()
+    c.Header("Content-Language", i18n.MessageLanguage(ctx).String())
+    return i18n.Translate(ctx, key)
 }
\ No newline at end of file
@@ -9,9 +9,21 @@ CREATE TABLE IF NOT EXISTS testimonials (
     last_name VARCHAR(100) NOT NULL,
     full_name VARCHAR(200) GENERATED ALWAYS AS (first_name || ' ' || last_name) STORED,
     image_url VARCHAR(500),
+    thumbnail_url VARCHAR(500),
     testimony TEXT NOT NULL,
+    language VARCHAR(35) NOT NULL DEFAULT 'en',
     is_anonymous BOOLEAN DEFAULT FALSE,
     is_approved BOOLEAN DEFAULT FALSE,
+    is_flagged BOOLEAN DEFAULT FALSE,
+    flag_reasons TEXT,
+    content_hash VARCHAR(64),
+    is_featured BOOLEAN NOT NULL DEFAULT FALSE,
+    feature_order INTEGER NOT NULL DEFAULT 0,
+    featured_from TIMESTAMP WITH TIME ZONE,
+    featured_until TIMESTAMP WITH TIME ZONE,
+    tags JSONB NOT NULL DEFAULT '[]'::jsonb,
+    rejected_at TIMESTAMP WITH TIME ZONE,
+    version BIGINT NOT NULL DEFAULT 1,
     created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP WITH TIME ZONE -- ADD THIS LINE
@@ -21,6 +33,11 @@ CREATE TABLE IF NOT EXISTS testimonials (
 CREATE INDEX idx_testimonials_approved ON testimonials(is_approved);
 CREATE INDEX idx_testimonials_created_at ON testimonials(created_at DESC);
 CREATE INDEX idx_testimonials_deleted_at ON testimonials(deleted_at); -- ADD THIS LINE
+CREATE INDEX idx_testimonials_flagged ON testimonials(is_flagged);
+CREATE INDEX idx_testimonials_content_hash ON testimonials(content_hash);
+CREATE INDEX idx_testimonials_featured ON testimonials(is_featured);
+CREATE INDEX idx_testimonials_feature_order ON testimonials(feature_order) WHERE is_featured;
+CREATE INDEX idx_testimonials_tags ON testimonials USING GIN (tags);
 
 -- Updated_at trigger
 CREATE OR REPLACE FUNCTION update_updated_at_column()
@@ -35,6 +52,97 @@ CREATE TRIGGER update_testimonials_updated_at
     BEFORE UPDATE ON testimonials
     FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
 
+-- Video and audio attachments
+CREATE TABLE IF NOT EXISTS testimonial_media (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    testimonial_id UUID NOT NULL REFERENCES testimonials(id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL CHECK (kind IN ('video', 'audio')),
+    mime_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL,
+    duration_seconds DOUBLE PRECISION,
+    original_url VARCHAR(500) NOT NULL,
+    transcoded_url VARCHAR(500),
+    transcode_status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    transcode_error TEXT,
+    transcript TEXT,
+    is_approved BOOLEAN NOT NULL DEFAULT false,
+    is_flagged BOOLEAN NOT NULL DEFAULT false,
+    flag_reasons TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_testimonial_media_testimonial_id ON testimonial_media(testimonial_id);
+CREATE INDEX idx_testimonial_media_deleted_at ON testimonial_media(deleted_at);
+
+CREATE TRIGGER update_testimonial_media_updated_at
+    BEFORE UPDATE ON testimonial_media
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Edit history
+CREATE TABLE IF NOT EXISTS testimonial_revisions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    testimonial_id UUID NOT NULL REFERENCES testimonials(id) ON DELETE CASCADE,
+    version BIGINT NOT NULL,
+    action VARCHAR(20) NOT NULL,
+    actor VARCHAR(100) NOT NULL,
+    request_id VARCHAR(128),
+    changes JSONB NOT NULL DEFAULT '{}'::jsonb,
+    snapshot JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_testimonial_revisions_testimonial_id ON testimonial_revisions(testimonial_id, version DESC);
+
+-- Reaction totals; per-visitor deduplication lives in Redis
+CREATE TABLE IF NOT EXISTS testimonial_reaction_counts (
+    testimonial_id UUID NOT NULL REFERENCES testimonials(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('amen', 'praise', 'prayed')),
+    count BIGINT NOT NULL DEFAULT 0 CHECK (count >= 0),
+    PRIMARY KEY (testimonial_id, kind)
+);
+
+-- Comments, threaded one level deep
+CREATE TABLE IF NOT EXISTS testimonial_comments (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    testimonial_id UUID NOT NULL REFERENCES testimonials(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES testimonial_comments(id) ON DELETE CASCADE,
+    author_name VARCHAR(100) NOT NULL,
+    author_email VARCHAR(254),
+    body TEXT NOT NULL,
+    is_approved BOOLEAN NOT NULL DEFAULT FALSE,
+    is_flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    flag_reasons TEXT,
+    rejected_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX idx_testimonial_comments_testimonial_id ON testimonial_comments(testimonial_id, created_at);
+CREATE INDEX idx_testimonial_comments_parent_id ON testimonial_comments(parent_id);
+CREATE INDEX idx_testimonial_comments_moderation ON testimonial_comments(is_approved, is_flagged);
+CREATE INDEX idx_testimonial_comments_deleted_at ON testimonial_comments(deleted_at);
+
+CREATE TRIGGER update_testimonial_comments_updated_at
+    BEFORE UPDATE ON testimonial_comments
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+-- Translations of a testimony into languages other than its original
+CREATE TABLE IF NOT EXISTS testimonial_translations (
+    testimonial_id UUID NOT NULL REFERENCES testimonials(id) ON DELETE CASCADE,
+    language VARCHAR(35) NOT NULL,
+    testimony TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (testimonial_id, language)
+);
+
+CREATE TRIGGER update_testimonial_translations_updated_at
+    BEFORE UPDATE ON testimonial_translations
+    FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
 -- Insert sample testimonials WITHOUT role
 INSERT INTO testimonials (first_name, last_name, testimony, is_approved) VALUES
     ('Michael', 'Johnson', 'I was lost in addiction for 15 years. Through the prayer ministry of this church and God''s grace, I''ve been sober for 3 years now. The support I received here changed my life completely.', true),